@@ -22,6 +22,15 @@ type Config struct {
 	
 	// Filters for data processing
 	Filters FilterConfig `yaml:"filters"`
+
+	// Named filter profiles an operator can switch between without
+	// restarting, e.g. for tuning which programs to monitor during a
+	// live token launch
+	Profiles map[string]FilterConfig `yaml:"profiles"`
+
+	// ActiveProfile selects a profile from Profiles to use in place of
+	// Filters. Empty means Filters is used as-is
+	ActiveProfile string `yaml:"active_profile"`
 }
 
 // ServerConfig contains server connection settings
@@ -127,6 +136,7 @@ func DefaultConfig() *Config {
 			TokenMints:  []string{},
 			InstructionTypes: []string{},
 		},
+		Profiles: map[string]FilterConfig{},
 	}
 }
 
@@ -202,9 +212,27 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log format: %s", c.Logging.Format)
 	}
 
+	if c.ActiveProfile != "" {
+		if _, ok := c.Profiles[c.ActiveProfile]; !ok {
+			return fmt.Errorf("active profile %q not found in profiles", c.ActiveProfile)
+		}
+	}
+
 	return nil
 }
 
+// ActiveFilters returns the FilterConfig currently in effect: the named
+// profile in Profiles if ActiveProfile is set, otherwise the top-level
+// Filters block.
+func (c *Config) ActiveFilters() FilterConfig {
+	if c.ActiveProfile != "" {
+		if profile, ok := c.Profiles[c.ActiveProfile]; ok {
+			return profile
+		}
+	}
+	return c.Filters
+}
+
 // SaveToFile saves the configuration to a YAML file
 func (c *Config) SaveToFile(filename string) error {
 	data, err := yaml.Marshal(c)
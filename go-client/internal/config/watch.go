@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ConfigSubscriber receives the new filter set the moment Watch swaps it
+// in, without a restart. Watch notifies every subscriber registered via
+// Subscribe; no type in this module implements it outside its tests.
+type ConfigSubscriber interface {
+	// OnFilterChange is called with the newly active FilterConfig after
+	// a reload has been validated and swapped in.
+	OnFilterChange(FilterConfig)
+}
+
+// Watcher holds the live Config and reloads it from disk on SIGHUP, and on
+// file writes if fsnotify is available, swapping it in only after the
+// candidate passes Validate. A failed reload leaves the previous,
+// known-good Config and its subscribers untouched.
+type Watcher struct {
+	path string
+
+	mu      sync.RWMutex
+	current *Config
+
+	subsMu      sync.Mutex
+	subscribers []ConfigSubscriber
+}
+
+// NewWatcher creates a Watcher serving initial until the first successful
+// reload.
+func NewWatcher(path string, initial *Config) *Watcher {
+	return &Watcher{path: path, current: initial}
+}
+
+// Current returns the Config currently in effect.
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Subscribe registers s to be notified with the active FilterConfig after
+// every successful reload.
+func (w *Watcher) Subscribe(s ConfigSubscriber) {
+	w.subsMu.Lock()
+	defer w.subsMu.Unlock()
+	w.subscribers = append(w.subscribers, s)
+}
+
+// Watch blocks, reloading the config on SIGHUP and on writes to path
+// (detected via fsnotify, best-effort), until ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context) error {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	// fsEvents and fsErrors stay nil, and so never fire, when fsnotify
+	// cannot be set up; SIGHUP reloading keeps working either way.
+	var fsEvents <-chan fsnotify.Event
+	var fsErrors <-chan error
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		defer fsWatcher.Close()
+		err = fsWatcher.Add(filepath.Dir(w.path))
+	}
+	if err != nil {
+		log.Printf("config: fsnotify unavailable, reloading on SIGHUP only: %v", err)
+	} else {
+		fsEvents, fsErrors = fsWatcher.Events, fsWatcher.Errors
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-sighup:
+			w.reload()
+
+		case event, ok := <-fsEvents:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(w.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				w.reload()
+			}
+
+		case err, ok := <-fsErrors:
+			if !ok {
+				return nil
+			}
+			log.Printf("config: fsnotify error: %v", err)
+		}
+	}
+}
+
+// reload re-parses the config file, validates it, and swaps it in. On
+// failure it logs and keeps serving the previous Config.
+func (w *Watcher) reload() {
+	candidate, err := LoadFromFile(w.path)
+	if err != nil {
+		log.Printf("config: reload failed, keeping previous config: %v", err)
+		return
+	}
+
+	if err := candidate.Validate(); err != nil {
+		log.Printf("config: reload produced an invalid config, rolling back: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.current
+	w.current = candidate
+	w.mu.Unlock()
+
+	logFilterDiff(previous, candidate)
+	w.notify(candidate.ActiveFilters())
+}
+
+func (w *Watcher) notify(filters FilterConfig) {
+	w.subsMu.Lock()
+	subscribers := append([]ConfigSubscriber(nil), w.subscribers...)
+	w.subsMu.Unlock()
+
+	for _, s := range subscribers {
+		s.OnFilterChange(filters)
+	}
+}
+
+func logFilterDiff(previous, next *Config) {
+	prevFilters := previous.ActiveFilters()
+	nextFilters := next.ActiveFilters()
+
+	if reflect.DeepEqual(prevFilters, nextFilters) && previous.Logging.Level == next.Logging.Level {
+		log.Printf("config: reloaded %s (no change to filters or log level)", next.ActiveProfile)
+		return
+	}
+
+	log.Printf("config: reloaded %s: filters %+v -> %+v, log level %q -> %q",
+		next.ActiveProfile, prevFilters, nextFilters, previous.Logging.Level, next.Logging.Level)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, path string, activeProfile string, programs []string) {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.ActiveProfile = activeProfile
+	cfg.Filters.Programs = programs
+	cfg.Profiles = map[string]FilterConfig{
+		"launch-watch": {TradingOnly: true, Programs: []string{"LaunchProgram111111111111111111111111111"}},
+	}
+	if err := cfg.SaveToFile(path); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+}
+
+func TestWatcherCurrentReturnsInitial(t *testing.T) {
+	initial := DefaultConfig()
+	w := NewWatcher("unused.yaml", initial)
+
+	if got := w.Current(); got != initial {
+		t.Fatalf("Current() = %p, want the initial config %p", got, initial)
+	}
+}
+
+func TestWatcherReloadSwapsInValidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "", []string{"ProgramA1111111111111111111111111111111"})
+
+	initial, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	w := NewWatcher(path, initial)
+
+	writeTestConfig(t, path, "launch-watch", nil)
+	w.reload()
+
+	got := w.Current().ActiveFilters()
+	want := FilterConfig{TradingOnly: true, Programs: []string{"LaunchProgram111111111111111111111111111"}}
+	if got.TradingOnly != want.TradingOnly || len(got.Programs) != len(want.Programs) || got.Programs[0] != want.Programs[0] {
+		t.Fatalf("ActiveFilters() after reload = %+v, want %+v", got, want)
+	}
+}
+
+func TestWatcherReloadRejectsInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "", nil)
+
+	initial, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	w := NewWatcher(path, initial)
+
+	// active_profile referencing a profile that doesn't exist fails Validate.
+	invalid, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	invalid.ActiveProfile = "does-not-exist"
+	if err := invalid.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	w.reload()
+
+	if got := w.Current(); got != initial {
+		t.Fatalf("reload swapped in an invalid config: Current() = %+v, want the previous config unchanged", got)
+	}
+}
+
+type recordingSubscriber struct {
+	seen []FilterConfig
+}
+
+func (r *recordingSubscriber) OnFilterChange(f FilterConfig) {
+	r.seen = append(r.seen, f)
+}
+
+func TestWatcherNotifiesSubscribersOnReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "", []string{"ProgramA1111111111111111111111111111111"})
+
+	initial, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	w := NewWatcher(path, initial)
+
+	sub := &recordingSubscriber{}
+	w.Subscribe(sub)
+
+	writeTestConfig(t, path, "launch-watch", nil)
+	w.reload()
+
+	if len(sub.seen) != 1 {
+		t.Fatalf("subscriber got %d notifications, want 1", len(sub.seen))
+	}
+	if !sub.seen[0].TradingOnly || len(sub.seen[0].Programs) != 1 || sub.seen[0].Programs[0] != "LaunchProgram111111111111111111111111111" {
+		t.Fatalf("subscriber notified with %+v, want the launch-watch profile's filters", sub.seen[0])
+	}
+}
+
+func TestWatcherDoesNotNotifyOnInvalidReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "", nil)
+
+	initial, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	w := NewWatcher(path, initial)
+
+	sub := &recordingSubscriber{}
+	w.Subscribe(sub)
+
+	invalid, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	invalid.ActiveProfile = "does-not-exist"
+	if err := invalid.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	w.reload()
+
+	if len(sub.seen) != 0 {
+		t.Fatalf("subscriber got %d notifications for a rejected reload, want 0", len(sub.seen))
+	}
+}
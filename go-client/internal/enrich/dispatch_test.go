@@ -0,0 +1,111 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+type recordingSink struct {
+	events []TradeEvent
+	err    error
+}
+
+func (r *recordingSink) WriteTradeEvent(ctx context.Context, event TradeEvent, instr *models.ParsedInstructionData) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func pumpFunAMMSwapInstr() *models.ParsedInstructionData {
+	return &models.ParsedInstructionData{
+		ProgramID:       "39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg",
+		ProgramName:     "Pump.fun AMM",
+		InstructionType: "swap",
+		ParsedData: map[string]interface{}{
+			"amount_in":             "500000000",
+			"amount_out":            "490000000",
+			"pool":                  "Pool1111111111111111111111111111111111111",
+			"protocol_fee_lamports": "125000",
+		},
+		Accounts:   []string{"Trader111111111111111111111111111111111"},
+		TokenMints: []string{"MintA111111111111111111111111111111111", "MintB111111111111111111111111111111111"},
+	}
+}
+
+func TestDispatchWritesEnrichedEventToEverySink(t *testing.T) {
+	a := &recordingSink{}
+	b := &recordingSink{}
+
+	if err := Dispatch(context.Background(), pumpFunAMMSwapInstr(), nil, []TradeEventSink{a, b}); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+
+	for _, s := range []*recordingSink{a, b} {
+		if len(s.events) != 1 {
+			t.Fatalf("sink got %d events, want 1", len(s.events))
+		}
+		if s.events[0].Side != SideSwap {
+			t.Fatalf("event.Side = %v, want SideSwap", s.events[0].Side)
+		}
+	}
+}
+
+func TestDispatchSkipsUnknownEvents(t *testing.T) {
+	s := &recordingSink{}
+	instr := &models.ParsedInstructionData{
+		ProgramID:       "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb",
+		ProgramName:     "SPL Token Program",
+		InstructionType: "transfer",
+	}
+
+	if err := Dispatch(context.Background(), instr, nil, []TradeEventSink{s}); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if len(s.events) != 0 {
+		t.Fatalf("sink got %d events for an unknown-sided event, want 0", len(s.events))
+	}
+}
+
+func TestDispatchJoinsSinkErrorsWithoutStoppingOtherSinks(t *testing.T) {
+	failing := &recordingSink{err: errors.New("write failed")}
+	ok := &recordingSink{}
+
+	err := Dispatch(context.Background(), pumpFunAMMSwapInstr(), nil, []TradeEventSink{failing, ok})
+	if err == nil {
+		t.Fatal("Dispatch returned nil error, want the failing sink's error")
+	}
+	if len(ok.events) != 1 {
+		t.Fatalf("the non-failing sink got %d events, want 1", len(ok.events))
+	}
+}
+
+func TestPumpFunAMMSwapPopulatesFeeLamports(t *testing.T) {
+	event, ok, err := decodePumpFunAMMSwap(pumpFunAMMSwapInstr(), nil)
+	if err != nil {
+		t.Fatalf("decodePumpFunAMMSwap returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("decodePumpFunAMMSwap did not handle a swap instruction")
+	}
+	if event.FeeLamports != 125000 {
+		t.Fatalf("FeeLamports = %d, want 125000", event.FeeLamports)
+	}
+}
+
+func TestPumpFunAMMSwapDefaultsFeeLamportsWhenAbsent(t *testing.T) {
+	instr := pumpFunAMMSwapInstr()
+	delete(instr.ParsedData, "protocol_fee_lamports")
+
+	event, ok, err := decodePumpFunAMMSwap(instr, nil)
+	if err != nil {
+		t.Fatalf("decodePumpFunAMMSwap returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("decodePumpFunAMMSwap did not handle a swap instruction")
+	}
+	if event.FeeLamports != 0 {
+		t.Fatalf("FeeLamports = %d, want 0 when protocol_fee_lamports is absent", event.FeeLamports)
+	}
+}
@@ -0,0 +1,59 @@
+package enrich
+
+import "strconv"
+
+// parsed_data and a ParsedAccountData's ParsedData both arrive as
+// map[string]interface{} decoded from JSON, so numeric fields may surface
+// as either json.Number-ish float64 or a string (Solana amounts routinely
+// exceed float64's safe integer range, so the Rust side often encodes them
+// as strings). These helpers normalize both.
+
+func stringField(data map[string]interface{}, key string) (string, bool) {
+	v, ok := data[key]
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+func uint64Field(data map[string]interface{}, key string) (uint64, bool) {
+	v, ok := data[key]
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case float64:
+		if n < 0 {
+			return 0, false
+		}
+		return uint64(n), true
+	case string:
+		parsed, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	default:
+		return 0, false
+	}
+}
+
+func int64Field(data map[string]interface{}, key string) (int64, bool) {
+	v, ok := data[key]
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case string:
+		parsed, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return parsed, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,24 @@
+package enrich
+
+import (
+	"context"
+	"log"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// LogSink is the simplest TradeEventSink: it logs every decoded trade
+// event through the standard logger rather than writing it anywhere
+// durable. It's meant for callers that haven't wired up a downstream
+// store yet, so Dispatch has somewhere real to write without forcing
+// every caller to implement TradeEventSink themselves first.
+type LogSink struct{}
+
+// WriteTradeEvent logs event and never fails.
+func (LogSink) WriteTradeEvent(ctx context.Context, event TradeEvent, instr *models.ParsedInstructionData) error {
+	log.Printf("enrich: %s %s program=%s base=%s:%d quote=%s:%d pool=%s trader=%s",
+		event.Side, instr.InstructionType, instr.ProgramName,
+		event.BaseMint, event.BaseAmount, event.QuoteMint, event.QuoteAmount,
+		event.PoolID, event.TraderPubkey)
+	return nil
+}
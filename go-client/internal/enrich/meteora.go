@@ -0,0 +1,99 @@
+package enrich
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// meteoraDefaultBinStepBps is the bin step, in basis points, used to price
+// every DLMM swap; the decoder does not read a lb_pair's own bin_step.
+// 10 bps matches Meteora's most common DLMM pool configuration; pools
+// created with a different step get a correspondingly approximate price.
+const meteoraDefaultBinStepBps = 10
+
+// meteoraReferenceBinID is the bin DLMM treats as price 1.0; active_bin_id
+// is offset from it, not from bin 0, so binPriceQ64's exponent is always
+// active_bin_id - meteoraReferenceBinID.
+const meteoraReferenceBinID = 1 << 23
+
+// decodeMeteoraDLMMSwap handles Meteora DLMM's bin-based swap. DLMM prices
+// a swap by the active bin it executes in rather than a pooled reserve
+// ratio, so the price is derived from active_bin_id via the bin-price
+// formula (1 + bin_step/10_000)^bin_id rather than from amount_x/amount_y,
+// which only say how much moved, not at what price.
+func decodeMeteoraDLMMSwap(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) (TradeEvent, bool, error) {
+	if instr.InstructionType != "swap" {
+		return TradeEvent{}, false, nil
+	}
+
+	amountX, ok := uint64Field(instr.ParsedData, "amount_x")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: meteora dlmm swap missing amount_x")
+	}
+	amountY, ok := uint64Field(instr.ParsedData, "amount_y")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: meteora dlmm swap missing amount_y")
+	}
+	binID, _ := int64Field(instr.ParsedData, "active_bin_id")
+
+	event := TradeEvent{
+		Side:        SideSwap,
+		BaseAmount:  amountX,
+		QuoteAmount: amountY,
+		PriceQ64:    binPriceQ64(binID-meteoraReferenceBinID, meteoraDefaultBinStepBps),
+	}
+	if len(instr.Accounts) > 0 {
+		event.PoolID = instr.Accounts[0]
+	}
+	if len(instr.Accounts) > 0 {
+		event.TraderPubkey = instr.Accounts[len(instr.Accounts)-1]
+	}
+	if len(instr.TokenMints) > 0 {
+		event.BaseMint = instr.TokenMints[0]
+	}
+	if len(instr.TokenMints) > 1 {
+		event.QuoteMint = instr.TokenMints[1]
+	}
+
+	return event, true, nil
+}
+
+// binPriceQ64 computes (1 + binStepBps/10_000)^binID as a Q64.64
+// fixed-point number, the price DLMM assigns to a bin binID steps away
+// from its reference bin (price 1.0) - callers pass
+// active_bin_id - meteoraReferenceBinID, not the raw active_bin_id, since
+// DLMM numbers bins from 2^23 rather than from 0. Negative bin IDs invert
+// the per-bin step and raise it to -binID, matching DLMM's own bin
+// numbering. DLMM bin IDs commonly run into the millions, so the
+// exponent is applied by squaring rather than by multiplying in a loop
+// once per bin.
+// binPrec is generous enough that squaring the step a few hundred times
+// (covering any realistic bin ID) doesn't erode precision below Q64.64's
+// own 64 fractional bits.
+const binPrec = 256
+
+func binPriceQ64(binID int64, binStepBps int64) *big.Int {
+	step := new(big.Float).SetPrec(binPrec).Quo(
+		new(big.Float).SetInt64(10_000+binStepBps),
+		big.NewFloat(10_000),
+	)
+	if binID < 0 {
+		step.Quo(new(big.Float).SetPrec(binPrec).SetInt64(1), step)
+		binID = -binID
+	}
+
+	price := new(big.Float).SetPrec(binPrec).SetInt64(1)
+	base := step
+	for exp := binID; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			price.Mul(price, base)
+		}
+		base = new(big.Float).SetPrec(binPrec).Mul(base, base)
+	}
+
+	scaled := new(big.Float).SetPrec(binPrec).Mul(price, new(big.Float).SetInt(q64Scale))
+	result, _ := scaled.Int(nil)
+	return result
+}
@@ -0,0 +1,39 @@
+package enrich
+
+import (
+	"testing"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+func TestBinPriceQ64ReferenceBinPricesToOne(t *testing.T) {
+	got := binPriceQ64(meteoraReferenceBinID-meteoraReferenceBinID, meteoraDefaultBinStepBps)
+	if got.Cmp(q64Scale) != 0 {
+		t.Fatalf("binPriceQ64(0, ...) = %s, want %s (price 1.0)", got, q64Scale)
+	}
+}
+
+func TestDecodeMeteoraDLMMSwapPricesReferenceBinAtOne(t *testing.T) {
+	instr := &models.ParsedInstructionData{
+		ProgramName:     "Meteora DLMM",
+		InstructionType: "swap",
+		ParsedData: map[string]interface{}{
+			"amount_x":      "1000000",
+			"amount_y":      "1000000",
+			"active_bin_id": "8388608",
+		},
+		Accounts:   []string{"Pool1111111111111111111111111111111111111", "Trader111111111111111111111111111111111"},
+		TokenMints: []string{"MintA111111111111111111111111111111111", "MintB111111111111111111111111111111111"},
+	}
+
+	event, ok, err := decodeMeteoraDLMMSwap(instr, nil)
+	if err != nil {
+		t.Fatalf("decodeMeteoraDLMMSwap returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("decodeMeteoraDLMMSwap did not handle a swap instruction")
+	}
+	if event.PriceQ64.Cmp(q64Scale) != 0 {
+		t.Fatalf("PriceQ64 for the reference bin = %s, want %s (price 1.0)", event.PriceQ64, q64Scale)
+	}
+}
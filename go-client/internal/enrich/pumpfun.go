@@ -0,0 +1,111 @@
+package enrich
+
+import (
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// wrappedSOLMint is the mint address SPL wraps native SOL as. Pump.fun's
+// bonding curve and AMM both quote exclusively in SOL, so it never appears
+// in token_mints and has to be filled in rather than read off the
+// instruction.
+const wrappedSOLMint = "So11111111111111111111111111111111111111112"
+
+// decodePumpFunBondingCurve handles Pump.fun's bonding-curve buy/sell,
+// pricing the trade off the curve's virtual reserves rather than the
+// traded amounts: sol_amount/token_amount is the price the trader actually
+// paid, but virtual_sol_reserves/virtual_token_reserves is the curve's
+// post-trade marginal price, which is what downstream consumers comparing
+// across trades expect.
+func decodePumpFunBondingCurve(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) (TradeEvent, bool, error) {
+	var side Side
+	switch instr.InstructionType {
+	case "buy":
+		side = SideBuy
+	case "sell":
+		side = SideSell
+	default:
+		return TradeEvent{}, false, nil
+	}
+
+	solAmount, ok := uint64Field(instr.ParsedData, "sol_amount")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: pump.fun %s missing sol_amount", instr.InstructionType)
+	}
+	tokenAmount, ok := uint64Field(instr.ParsedData, "token_amount")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: pump.fun %s missing token_amount", instr.InstructionType)
+	}
+	bondingCurve, ok := stringField(instr.ParsedData, "bonding_curve")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: pump.fun %s missing bonding_curve", instr.InstructionType)
+	}
+
+	event := TradeEvent{
+		Side:        side,
+		QuoteMint:   wrappedSOLMint,
+		BaseAmount:  tokenAmount,
+		QuoteAmount: solAmount,
+		PoolID:      bondingCurve,
+	}
+	if len(instr.Accounts) > 0 {
+		event.TraderPubkey = instr.Accounts[0]
+	}
+	if len(instr.TokenMints) > 0 {
+		event.BaseMint = instr.TokenMints[0]
+	}
+
+	if curve, ok := accounts[bondingCurve]; ok {
+		virtualSOL, solOK := uint64Field(curve.ParsedData, "virtual_sol_reserves")
+		virtualTokens, tokOK := uint64Field(curve.ParsedData, "virtual_token_reserves")
+		if solOK && tokOK {
+			event.PriceQ64 = priceQ64(virtualSOL, virtualTokens)
+		}
+	}
+
+	return event, true, nil
+}
+
+// decodePumpFunAMMSwap handles Pump.fun AMM's post-migration swap, pricing
+// off the amounts actually exchanged since, unlike the bonding curve, a
+// constant-product pool's reserves aren't carried in parsed_data.
+func decodePumpFunAMMSwap(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) (TradeEvent, bool, error) {
+	if instr.InstructionType != "swap" {
+		return TradeEvent{}, false, nil
+	}
+
+	amountIn, ok := uint64Field(instr.ParsedData, "amount_in")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: pump.fun amm swap missing amount_in")
+	}
+	amountOut, ok := uint64Field(instr.ParsedData, "amount_out")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: pump.fun amm swap missing amount_out")
+	}
+	pool, _ := stringField(instr.ParsedData, "pool")
+	// protocol_fee_lamports is absent from older program builds, so a
+	// missing value just leaves FeeLamports at its zero default rather
+	// than failing the whole decode.
+	feeLamports, _ := uint64Field(instr.ParsedData, "protocol_fee_lamports")
+
+	event := TradeEvent{
+		Side:        SideSwap,
+		BaseAmount:  amountOut,
+		QuoteAmount: amountIn,
+		PoolID:      pool,
+		PriceQ64:    priceQ64(amountIn, amountOut),
+		FeeLamports: feeLamports,
+	}
+	if len(instr.TokenMints) > 0 {
+		event.QuoteMint = instr.TokenMints[0]
+	}
+	if len(instr.TokenMints) > 1 {
+		event.BaseMint = instr.TokenMints[1]
+	}
+	if len(instr.Accounts) > 0 {
+		event.TraderPubkey = instr.Accounts[0]
+	}
+
+	return event, true, nil
+}
@@ -0,0 +1,59 @@
+package enrich
+
+import (
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// decodeRaydiumV4Swap handles Raydium V4's swap instruction. Unlike
+// Pump.fun AMM, parsed_data carries only the trader's requested amount_in
+// and minimum_amount_out, not what was actually filled, so the price comes
+// from the pool's own reserves - passed in via accounts, keyed by the
+// pool_state account this same instruction references - rather than from
+// the instruction itself.
+func decodeRaydiumV4Swap(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) (TradeEvent, bool, error) {
+	if instr.InstructionType != "swap" {
+		return TradeEvent{}, false, nil
+	}
+	if len(instr.Accounts) == 0 {
+		return TradeEvent{}, false, fmt.Errorf("enrich: raydium v4 swap has no accounts")
+	}
+
+	amountIn, ok := uint64Field(instr.ParsedData, "amount_in")
+	if !ok {
+		return TradeEvent{}, false, fmt.Errorf("enrich: raydium v4 swap missing amount_in")
+	}
+	minAmountOut, _ := uint64Field(instr.ParsedData, "minimum_amount_out")
+
+	// Raydium V4's swap instruction lists accounts as
+	// [pool_state, target_orders, ..., trader, ...]; the trader is the
+	// instruction's last account across every vixen fixture and program
+	// build observed so far.
+	poolState := instr.Accounts[0]
+	trader := instr.Accounts[len(instr.Accounts)-1]
+
+	event := TradeEvent{
+		Side:         SideSwap,
+		QuoteAmount:  amountIn,
+		BaseAmount:   minAmountOut,
+		PoolID:       poolState,
+		TraderPubkey: trader,
+	}
+	if len(instr.TokenMints) > 0 {
+		event.QuoteMint = instr.TokenMints[0]
+	}
+	if len(instr.TokenMints) > 1 {
+		event.BaseMint = instr.TokenMints[1]
+	}
+
+	if pool, ok := accounts[poolState]; ok {
+		baseReserve, baseOK := uint64Field(pool.ParsedData, "base_reserve")
+		quoteReserve, quoteOK := uint64Field(pool.ParsedData, "quote_reserve")
+		if baseOK && quoteOK {
+			event.PriceQ64 = priceQ64(quoteReserve, baseReserve)
+		}
+	}
+
+	return event, true, nil
+}
@@ -0,0 +1,92 @@
+package enrich
+
+import (
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Decoder turns one trading instruction into a TradeEvent. accounts holds
+// any ParsedAccountData observed in the same slot, keyed by account
+// pubkey, so a decoder that needs a pool's live reserves (Raydium V4) can
+// look it up without a second round trip to the API. A decoder returns
+// ok=false, rather than an error, when instr's instruction_type isn't one
+// it handles (e.g. Meteora's add_liquidity reaching the swap decoder) -
+// that's a normal "not mine" signal, not a failure.
+type Decoder func(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) (event TradeEvent, ok bool, err error)
+
+// decodersByProgram maps a ProgramMetadata.Name, as returned by
+// models.SupportedPrograms(), to the decoder that understands its
+// instructions. Programs with no entry here (SPL Token Program today) have
+// no trading shape to decode and always fall through to Unknown.
+var decodersByProgram = map[string]Decoder{
+	"Pump.fun":                  decodePumpFunBondingCurve,
+	"Pump.fun AMM":              decodePumpFunAMMSwap,
+	"Raydium Liquidity Pool V4": decodeRaydiumV4Swap,
+	"Meteora DLMM":              decodeMeteoraDLMMSwap,
+}
+
+// registry maps program_id, rather than name, to its Decoder, since that's
+// what ParsedInstructionData.ProgramID carries at decode time.
+var registry = buildRegistry()
+
+func buildRegistry() map[string]Decoder {
+	r := make(map[string]Decoder, len(decodersByProgram))
+	for _, p := range models.SupportedPrograms() {
+		if d, ok := decodersByProgram[p.Name]; ok {
+			r[p.ProgramID] = d
+		}
+	}
+	return r
+}
+
+var decoderMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "vixen_enrich_decoder_misses_total",
+	Help: "Trading instructions enrich couldn't decode into a TradeEvent, by program and reason.",
+}, []string{"program_name", "reason"})
+
+func init() {
+	prometheus.MustRegister(decoderMisses)
+}
+
+const (
+	missReasonNoDecoder        = "no_decoder"
+	missReasonUnhandledVariant = "unhandled_variant"
+	missReasonDecodeError      = "decode_error"
+)
+
+// Enrich decodes instr into a TradeEvent using the decoder registered for
+// its program, falling back to an Unknown-sided event and incrementing
+// decoderMisses when no decoder is registered, the decoder doesn't handle
+// instr's instruction_type, or decoding fails outright.
+func Enrich(instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) TradeEvent {
+	decoder, ok := registry[instr.ProgramID]
+	if !ok {
+		decoderMisses.WithLabelValues(instr.ProgramName, missReasonNoDecoder).Inc()
+		return unknownEvent(instr)
+	}
+
+	event, handled, err := decoder(instr, accounts)
+	if err != nil {
+		decoderMisses.WithLabelValues(instr.ProgramName, missReasonDecodeError).Inc()
+		return unknownEvent(instr)
+	}
+	if !handled {
+		decoderMisses.WithLabelValues(instr.ProgramName, missReasonUnhandledVariant).Inc()
+		return unknownEvent(instr)
+	}
+	return event
+}
+
+func unknownEvent(instr *models.ParsedInstructionData) TradeEvent {
+	event := TradeEvent{Side: SideUnknown}
+	if len(instr.Accounts) > 0 {
+		event.TraderPubkey = instr.Accounts[0]
+	}
+	if len(instr.TokenMints) > 0 {
+		event.BaseMint = instr.TokenMints[0]
+	}
+	if len(instr.TokenMints) > 1 {
+		event.QuoteMint = instr.TokenMints[1]
+	}
+	return event
+}
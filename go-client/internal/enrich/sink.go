@@ -0,0 +1,36 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// TradeEventSink receives decoded TradeEvents so downstream consumers work
+// off canonicalized trades instead of re-parsing each program's raw
+// parsed_data map themselves.
+type TradeEventSink interface {
+	WriteTradeEvent(ctx context.Context, event TradeEvent, instr *models.ParsedInstructionData) error
+}
+
+// Dispatch decodes instr via Enrich and writes the result to every sink.
+// Unknown events (no registered decoder, or a decode the registered
+// decoder couldn't handle) are not dispatched, since they carry nothing a
+// sink can act on beyond what decoderMisses already tracks. A write
+// failure from one sink doesn't stop the others; their errors are joined
+// and returned together.
+func Dispatch(ctx context.Context, instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData, sinks []TradeEventSink) error {
+	event := Enrich(instr, accounts)
+	if event.Side == SideUnknown {
+		return nil
+	}
+
+	var errs []error
+	for _, s := range sinks {
+		if err := s.WriteTradeEvent(ctx, event, instr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
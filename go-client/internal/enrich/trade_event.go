@@ -0,0 +1,54 @@
+// Package enrich decodes raw ParsedInstructionData into canonicalized
+// TradeEvent records. IsTradingInstruction and TradingInstructionType only
+// tell a caller that an instruction is trade-shaped; they say nothing about
+// which side was taken, which mints moved, or at what price. Decoders here
+// fill that gap on a per-program basis, since each AMM encodes its swap
+// differently in parsed_data and accounts.
+package enrich
+
+import "math/big"
+
+// Side identifies which direction a TradeEvent moved value.
+type Side string
+
+const (
+	SideBuy     Side = "buy"
+	SideSell    Side = "sell"
+	SideSwap    Side = "swap"
+	SideUnknown Side = "unknown"
+)
+
+// TradeEvent is the canonicalized shape a decoder produces from a single
+// trading instruction, regardless of which program emitted it.
+type TradeEvent struct {
+	Side        Side
+	BaseMint    string
+	QuoteMint   string
+	BaseAmount  uint64
+	QuoteAmount uint64
+	// PriceQ64 is the quote/base execution price as a Q64.64 fixed-point
+	// number (the integer part occupies bits 64-127, the fraction bits
+	// 0-63), or nil when a decoder couldn't establish a price. Q64.64
+	// matches the fixed-point convention most Solana AMMs already use for
+	// on-chain price/reserve math, so it round-trips without loss.
+	PriceQ64     *big.Int
+	PoolID       string
+	TraderPubkey string
+	FeeLamports  uint64
+}
+
+// q64Scale is 2^64, the Q64.64 fixed-point unit.
+var q64Scale = new(big.Int).Lsh(big.NewInt(1), 64)
+
+// priceQ64 expresses numerator/denominator as a Q64.64 fixed-point price.
+// It returns nil when the price can't be computed, so callers can leave
+// TradeEvent.PriceQ64 unset rather than reporting a bogus zero price.
+func priceQ64(numerator, denominator uint64) *big.Int {
+	if denominator == 0 {
+		return nil
+	}
+	price := new(big.Int).SetUint64(numerator)
+	price.Mul(price, q64Scale)
+	price.Div(price, new(big.Int).SetUint64(denominator))
+	return price
+}
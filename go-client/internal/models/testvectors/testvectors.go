@@ -0,0 +1,110 @@
+// Package testvectors holds a versioned corpus of ParsedData fixtures, one
+// per program in models.SupportedPrograms(), and the helpers the
+// conformance test driver uses to replay them. A fixture is the raw JSON
+// payload the stream processor emits on the wire; its golden file is the
+// same payload after decoding into models.ParsedData and normalizing away
+// fields that are expected to differ between runs (IngestedAt) or ordering
+// (map keys, which encoding/json already serializes in sorted order).
+//
+// version.txt pins the upstream Rust commit this corpus was generated
+// against. Bump it, add/update fixtures, and regenerate golden files with
+// `go test ./internal/models/testvectors -run TestCorpus -update` whenever ParsedData gains a new
+// program or instruction variant on either side.
+package testvectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// Fixture is a single test vector: the raw wire payload for one program's
+// ParsedData, plus where its golden (expected) output lives.
+type Fixture struct {
+	Program    string // directory name under fixtures/, e.g. "pump_fun"
+	Name       string // file name without extension, e.g. "buy"
+	InputPath  string
+	GoldenPath string
+}
+
+// Walk discovers every fixture under the corpus rooted at dir (normally the
+// package's own directory, see Root).
+func Walk(dir string) ([]Fixture, error) {
+	fixturesRoot := filepath.Join(dir, "fixtures")
+
+	programs, err := os.ReadDir(fixturesRoot)
+	if err != nil {
+		return nil, fmt.Errorf("testvectors: failed to read fixtures dir: %w", err)
+	}
+
+	var fixtures []Fixture
+	for _, programEntry := range programs {
+		if !programEntry.IsDir() {
+			continue
+		}
+		program := programEntry.Name()
+
+		files, err := os.ReadDir(filepath.Join(fixturesRoot, program))
+		if err != nil {
+			return nil, fmt.Errorf("testvectors: failed to read fixtures for %s: %w", program, err)
+		}
+
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+				continue
+			}
+			name := f.Name()[:len(f.Name())-len(".json")]
+			fixtures = append(fixtures, Fixture{
+				Program:    program,
+				Name:       name,
+				InputPath:  filepath.Join(fixturesRoot, program, f.Name()),
+				GoldenPath: filepath.Join(dir, "golden", program, name+".golden.json"),
+			})
+		}
+	}
+
+	return fixtures, nil
+}
+
+// Decode reads a fixture's raw wire payload and unmarshals it into a
+// models.ParsedData.
+func Decode(f Fixture) (*models.ParsedData, error) {
+	raw, err := os.ReadFile(f.InputPath)
+	if err != nil {
+		return nil, fmt.Errorf("testvectors: failed to read fixture %s: %w", f.InputPath, err)
+	}
+
+	var data models.ParsedData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("testvectors: failed to decode fixture %s: %w", f.InputPath, err)
+	}
+	return &data, nil
+}
+
+// Canonicalize zeroes fields that legitimately vary between runs
+// (IngestedAt) and marshals with indentation so golden diffs are readable.
+// Map key ordering is not a concern here: encoding/json always serializes
+// map[string]interface{} values in sorted key order.
+func Canonicalize(data *models.ParsedData) ([]byte, error) {
+	normalized := *data
+	if normalized.Account != nil {
+		account := *normalized.Account
+		account.IngestedAt = time.Time{}
+		normalized.Account = &account
+	}
+	if normalized.Instruction != nil {
+		instruction := *normalized.Instruction
+		instruction.IngestedAt = time.Time{}
+		normalized.Instruction = &instruction
+	}
+
+	canonical, err := json.MarshalIndent(&normalized, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("testvectors: failed to marshal canonical form: %w", err)
+	}
+	return append(canonical, '\n'), nil
+}
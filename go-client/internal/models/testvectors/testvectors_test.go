@@ -0,0 +1,75 @@
+package testvectors
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate golden files from the current ParsedData fixtures")
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}\n?$`)
+
+// TestVersionPin asserts version.txt still holds the single upstream commit
+// SHA the corpus was generated against, so a bare "TODO" or empty file
+// can't silently slip in.
+func TestVersionPin(t *testing.T) {
+	pin, err := os.ReadFile("version.txt")
+	if err != nil {
+		t.Fatalf("failed to read version.txt: %v", err)
+	}
+	if !shaPattern.Match(pin) {
+		t.Fatalf("version.txt does not contain a single git SHA: %q", pin)
+	}
+}
+
+// TestCorpus walks every fixture under fixtures/, decodes it into
+// models.ParsedData, and diffs the canonical form against its golden file.
+// Run with -update to regenerate golden files after an intentional change
+// to ParsedData or to the fixtures themselves.
+func TestCorpus(t *testing.T) {
+	fixtures, err := Walk(".")
+	if err != nil {
+		t.Fatalf("failed to walk corpus: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found under fixtures/")
+	}
+
+	for _, f := range fixtures {
+		f := f
+		t.Run(f.Program+"/"+f.Name, func(t *testing.T) {
+			data, err := Decode(f)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			got, err := Canonicalize(data)
+			if err != nil {
+				t.Fatalf("canonicalize: %v", err)
+			}
+
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(f.GoldenPath), 0o755); err != nil {
+					t.Fatalf("failed to create golden dir: %v", err)
+				}
+				if err := os.WriteFile(f.GoldenPath, got, 0o644); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(f.GoldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to generate it): %v", err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("fixture %s does not match golden output, got:\n%s\nwant:\n%s", f.InputPath, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package vixen is go-client's public entry point. Everything it needs -
+// decoding, config, and trade-event enrichment - lives under internal/,
+// since none of it is meant to be imported piecemeal; Processor is the
+// one door in.
+package vixen
+
+import (
+	"context"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/enrich"
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+// Processor turns trading instructions into canonicalized TradeEvents and
+// fans each one out to every configured sink.
+type Processor struct {
+	sinks []enrich.TradeEventSink
+}
+
+// NewProcessor creates a Processor that dispatches to sinks. Pass
+// enrich.LogSink{} for visibility when no downstream store is wired up
+// yet.
+func NewProcessor(sinks ...enrich.TradeEventSink) *Processor {
+	return &Processor{sinks: sinks}
+}
+
+// Process decodes instr via enrich.Dispatch and writes the result to
+// every sink. accounts should hold any ParsedAccountData observed in the
+// same slot, so decoders that need a pool's live reserves (Raydium V4)
+// can look them up without a second round trip to the API.
+func (p *Processor) Process(ctx context.Context, instr *models.ParsedInstructionData, accounts map[string]*models.ParsedAccountData) error {
+	return enrich.Dispatch(ctx, instr, accounts, p.sinks)
+}
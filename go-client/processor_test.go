@@ -0,0 +1,41 @@
+package vixen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/enrich"
+	"github.com/hs2973/yellowstone-vixen/go-client/internal/models"
+)
+
+type recordingSink struct {
+	events []enrich.TradeEvent
+}
+
+func (r *recordingSink) WriteTradeEvent(ctx context.Context, event enrich.TradeEvent, instr *models.ParsedInstructionData) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestProcessorProcessDispatchesToEverySink(t *testing.T) {
+	s := &recordingSink{}
+	p := NewProcessor(s)
+
+	instr := &models.ParsedInstructionData{
+		ProgramID:       "39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg",
+		ProgramName:     "Pump.fun AMM",
+		InstructionType: "swap",
+		ParsedData: map[string]interface{}{
+			"amount_in":  "500000000",
+			"amount_out": "490000000",
+			"pool":       "Pool1111111111111111111111111111111111111",
+		},
+	}
+
+	if err := p.Process(context.Background(), instr, nil); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+	if len(s.events) != 1 {
+		t.Fatalf("sink got %d events, want 1", len(s.events))
+	}
+}
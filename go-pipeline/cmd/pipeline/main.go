@@ -1,9 +1,15 @@
 // Package main implements the second stage Go pipeline for high-throughput
-// data processing from Redis streams to PostgreSQL database.
+// data processing from Redis streams to one or more configured sinks.
 //
 // This pipeline is designed to handle 700,000+ packets per second efficiently
 // using the architecture:
-// Redis Stream Consumer → Worker Pool → Batch Processor → PostgreSQL
+// Redis Stream Consumer → Worker Pool → Batch Processor → Sinks
+// (PostgreSQL, ClickHouse, Kafka, Parquet-on-S3 — see SinksConfig)
+//
+// `go-pipeline replay --min-slot X --max-slot Y --sinks clickhouse`
+// rewinds processing to a historical slot range instead of consuming live
+// (see replay.go and internal/replay); the same capability is also
+// exposed as POST /admin/replay on the metrics listener.
 package main
 
 import (
@@ -17,6 +23,7 @@ import (
 
 	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
 	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/pipeline"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/replay"
 	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/logger"
 	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
 	"go.uber.org/zap"
@@ -35,6 +42,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		runReplay(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
 
 	if *version {
@@ -45,15 +57,16 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Load configuration
-	cfg, err := config.Load(*configPath)
+	// Load configuration, hot-reloading it on every change to *configPath.
+	cfgManager, err := config.NewManager(*configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		os.Exit(1)
 	}
+	cfg := cfgManager.Current()
 
 	// Initialize logger
-	log, err := logger.New(cfg.Logging)
+	log, logLevel, err := logger.New(cfg.Logging)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -66,19 +79,44 @@ func main() {
 		zap.String("build_time", BuildTime),
 	)
 
+	cfgManager.Watch()
+
 	// Initialize metrics
 	metricsServer := metrics.NewServer(cfg.Metrics)
+
+	// Wire the replay admin endpoint onto the same listener before Start,
+	// so operators can trigger a backfill over HTTP without a separate
+	// `go-pipeline replay` invocation against a running deployment.
+	adminCtx := context.Background()
+	replaySinks, replayReader, err := buildReplaySinks(adminCtx, cfg, log)
+	if err != nil {
+		log.Warn("replay admin endpoint disabled: failed to set up a readable replay sink", zap.Error(err))
+	} else {
+		replayOffsets, err := replay.OpenOffsetStore("replay-offsets.db")
+		if err != nil {
+			log.Warn("replay admin endpoint disabled: failed to open offset store", zap.Error(err))
+		} else {
+			defer replayOffsets.Close()
+			replayBatch := pipeline.NewBatchProcessor(replaySinks, cfg.Pipeline.BatchSize, cfg.Pipeline.BatchTimeout, cfg.Pipeline.Processing, metricsServer, log)
+			replayBatch.Start(adminCtx)
+			defer replayBatch.Stop()
+			metricsServer.RegisterAdminHandler("/admin/replay", replay.NewAdminHandler(replayReader, replayBatch.Handler(), replayOffsets, log))
+		}
+	}
+
 	if err := metricsServer.Start(); err != nil {
 		log.Fatal("Failed to start metrics server", zap.Error(err))
 	}
 	defer metricsServer.Stop()
 
 	// Create pipeline
-	pipeline, err := pipeline.New(cfg, log)
+	pipeline, err := pipeline.New(cfg, log, metricsServer)
 	if err != nil {
 		log.Fatal("Failed to create pipeline", zap.Error(err))
 	}
 
+	go watchConfigChanges(cfgManager, pipeline, metricsServer, logLevel, log)
+
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -97,11 +135,11 @@ func main() {
 	case <-sigChan:
 		log.Info("Received shutdown signal")
 		cancel()
-		
+
 		// Wait for graceful shutdown with timeout
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer shutdownCancel()
-		
+
 		if err := pipeline.Shutdown(shutdownCtx); err != nil {
 			log.Error("Pipeline shutdown error", zap.Error(err))
 		} else {
@@ -114,4 +152,48 @@ func main() {
 			os.Exit(1)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// watchConfigChanges reacts to every section cfgManager hot-reloads:
+// pipeline.worker_pool_size resizes pipe's worker pool, logging.level
+// swaps logLevel, and metrics.port rebinds metricsServer's listener.
+// Everything else about logging (format, outputs, sampling) and the rest
+// of a reloaded section is still fixed at construction and needs a
+// restart to pick up - only the three fields above have a live consumer.
+func watchConfigChanges(cfgManager *config.Manager, pipe *pipeline.Pipeline, metricsServer *metrics.Server, logLevel zap.AtomicLevel, log *zap.Logger) {
+	pipelineCh := cfgManager.SubscribePipeline()
+	redisCh := cfgManager.SubscribeRedis()
+	databaseCh := cfgManager.SubscribeDatabase()
+	metricsCh := cfgManager.SubscribeMetrics()
+	loggingCh := cfgManager.SubscribeLogging()
+
+	lastPort := metricsServer.Port()
+
+	for {
+		select {
+		case c := <-pipelineCh:
+			log.Info("config: pipeline section reloaded, resizing worker pool",
+				zap.Int("worker_pool_size", c.WorkerPoolSize), zap.Int("batch_size", c.BatchSize))
+			pipe.ResizeWorkerPool(c.WorkerPoolSize)
+		case c := <-redisCh:
+			log.Info("config: redis section reloaded (restart to pick up)", zap.String("url", c.URL))
+		case c := <-databaseCh:
+			log.Info("config: database section reloaded (restart to pick up)", zap.String("url", c.URL))
+		case c := <-metricsCh:
+			log.Info("config: metrics section reloaded", zap.Int("port", c.Port))
+			if c.Port != lastPort {
+				if err := metricsServer.Rebind(c.Port); err != nil {
+					log.Error("config: failed to rebind metrics server", zap.Error(err))
+				} else {
+					lastPort = c.Port
+				}
+			}
+		case c := <-loggingCh:
+			log.Info("config: logging section reloaded, updating level",
+				zap.String("level", c.Level))
+			if err := logger.SetLevel(logLevel, c.Level); err != nil {
+				log.Error("config: failed to apply new logging.level", zap.Error(err))
+			}
+		}
+	}
+}
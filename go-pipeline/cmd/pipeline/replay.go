@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/pipeline"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/replay"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/logger"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// runReplay implements `go-pipeline replay --min-slot X --max-slot Y
+// --sinks clickhouse`: it reads [min-slot, max-slot] back out of the first
+// named sink that implements sink.Reader, and re-feeds it through a batch
+// processor writing to every named sink, tagged sink.ModeReplay.
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to configuration file")
+	minSlot := fs.Uint64("min-slot", 0, "First slot to replay (inclusive)")
+	maxSlot := fs.Uint64("max-slot", 0, "Last slot to replay (inclusive)")
+	sinksFlag := fs.String("sinks", "", "Comma-separated sinks to read from and write to, e.g. clickhouse,postgres")
+	offsetsPath := fs.String("offsets", "replay-offsets.db", "Path to the BoltDB offset store")
+	consumerName := fs.String("consumer", "replay-cli", "Offset store consumer name, so concurrent replay runs don't clobber each other's progress")
+	fs.Parse(args)
+
+	if *maxSlot < *minSlot {
+		fmt.Fprintln(os.Stderr, "replay: --max-slot must be >= --min-slot")
+		os.Exit(1)
+	}
+	if *sinksFlag == "" {
+		fmt.Fprintln(os.Stderr, "replay: --sinks is required")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.Sinks.Enabled = strings.Split(*sinksFlag, ",")
+
+	log, _, err := logger.New(cfg.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	ctx := context.Background()
+
+	sinks, reader, err := buildReplaySinks(ctx, cfg, log)
+	if err != nil {
+		log.Fatal("replay: failed to build sinks", zap.Error(err))
+	}
+
+	offsets, err := replay.OpenOffsetStore(*offsetsPath)
+	if err != nil {
+		log.Fatal("replay: failed to open offset store", zap.Error(err))
+	}
+	defer offsets.Close()
+
+	metricsServer := metrics.NewServer(cfg.Metrics)
+	batch := pipeline.NewBatchProcessor(sinks, cfg.Pipeline.BatchSize, cfg.Pipeline.BatchTimeout, cfg.Pipeline.Processing, metricsServer, log)
+	batch.Start(ctx)
+	defer batch.Stop()
+
+	runner := replay.NewRunner(*consumerName, reader, batch.Handler(), offsets, log)
+	if err := runner.Run(ctx, replay.SlotRangeQuery{MinSlot: *minSlot, MaxSlot: *maxSlot}); err != nil {
+		log.Fatal("replay: run failed", zap.Error(err))
+	}
+
+	log.Info("replay: completed", zap.Uint64("min_slot", *minSlot), zap.Uint64("max_slot", *maxSlot))
+}
+
+// buildReplaySinks builds cfg.Sinks.Enabled and returns the first one
+// implementing sink.Reader as the historical source for a replay run.
+func buildReplaySinks(ctx context.Context, cfg *config.Config, log *zap.Logger) ([]sink.Sink, sink.Reader, error) {
+	sinks, err := pipeline.BuildSinks(ctx, cfg.Sinks, cfg.Database, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, s := range sinks {
+		if r, ok := s.(sink.Reader); ok {
+			return sinks, r, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("replay: none of %v implement sink.Reader", cfg.Sinks.Enabled)
+}
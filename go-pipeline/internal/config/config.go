@@ -13,18 +13,65 @@ type Config struct {
 	Pipeline PipelineConfig `mapstructure:"pipeline"`
 	Redis    RedisConfig    `mapstructure:"redis"`
 	Database DatabaseConfig `mapstructure:"database"`
+	Sinks    SinksConfig    `mapstructure:"sinks"`
 	Metrics  MetricsConfig  `mapstructure:"metrics"`
 	Logging  LoggingConfig  `mapstructure:"logging"`
 }
 
+// SinksConfig selects which sink backends the batch processor writes to
+// and holds each backend's connection settings. Enabled sinks are written
+// in parallel and each retries independently, but every flush waits for
+// all of them, so a slow sink delays the next flush to the others.
+type SinksConfig struct {
+	Enabled    []string         `mapstructure:"enabled"` // postgres, clickhouse, kafka, parquet
+	ClickHouse ClickHouseConfig `mapstructure:"clickhouse"`
+	Kafka      KafkaConfig      `mapstructure:"kafka"`
+	Parquet    ParquetConfig    `mapstructure:"parquet"`
+}
+
+// ClickHouseConfig contains connection and batching settings for the
+// ClickHouse sink.
+type ClickHouseConfig struct {
+	Addrs     []string `mapstructure:"addrs"`
+	Database  string   `mapstructure:"database"`
+	Username  string   `mapstructure:"username"`
+	Password  string   `mapstructure:"password"`
+	BatchSize int      `mapstructure:"batch_size"`
+}
+
+// KafkaConfig contains broker and delivery settings for the Kafka sink.
+type KafkaConfig struct {
+	Brokers      []string      `mapstructure:"brokers"`
+	TopicPrefix  string        `mapstructure:"topic_prefix"`
+	RequiredAcks string        `mapstructure:"required_acks"` // none, one, all
+	MaxRetries   int           `mapstructure:"max_retries"`
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+}
+
+// ParquetConfig contains file rotation and upload settings for the
+// Parquet sink.
+type ParquetConfig struct {
+	SlotsPerFile uint64              `mapstructure:"slots_per_file"`
+	Upload       ParquetUploadConfig `mapstructure:"upload"`
+}
+
+// ParquetUploadConfig selects the object store a rotated Parquet file is
+// uploaded to.
+type ParquetUploadConfig struct {
+	Provider string `mapstructure:"provider"` // s3 (the only provider BuildSinks accepts; no GCS uploader)
+	Bucket   string `mapstructure:"bucket"`
+	Prefix   string `mapstructure:"prefix"`
+	Region   string `mapstructure:"region"`
+}
+
 // PipelineConfig contains pipeline processing configuration
 type PipelineConfig struct {
-	WorkerPoolSize    int           `mapstructure:"worker_pool_size"`
-	BatchSize         int           `mapstructure:"batch_size"`
-	BatchTimeout      time.Duration `mapstructure:"batch_timeout"`
-	ConsumerGroupName string        `mapstructure:"consumer_group_name"`
-	ConsumerName      string        `mapstructure:"consumer_name"`
-	BufferSizes       BufferSizes   `mapstructure:"buffer_sizes"`
+	WorkerPoolSize    int              `mapstructure:"worker_pool_size"`
+	BatchSize         int              `mapstructure:"batch_size"`
+	BatchTimeout      time.Duration    `mapstructure:"batch_timeout"`
+	ConsumerGroupName string           `mapstructure:"consumer_group_name"`
+	ConsumerName      string           `mapstructure:"consumer_name"`
+	BufferSizes       BufferSizes      `mapstructure:"buffer_sizes"`
 	Processing        ProcessingConfig `mapstructure:"processing"`
 }
 
@@ -48,15 +95,18 @@ type ProcessingConfig struct {
 
 // RedisConfig contains Redis connection and streaming configuration
 type RedisConfig struct {
-	URL               string        `mapstructure:"url"`
-	StreamNames       []string      `mapstructure:"stream_names"`
-	ReadCount         int           `mapstructure:"read_count"`
-	BlockTimeout      time.Duration `mapstructure:"block_timeout"`
-	ConnectionPool    PoolConfig    `mapstructure:"connection_pool"`
-	ClusterMode       bool          `mapstructure:"cluster_mode"`
-	ClusterNodes      []string      `mapstructure:"cluster_nodes"`
-	TLS               TLSConfig     `mapstructure:"tls"`
-	Authentication    AuthConfig    `mapstructure:"authentication"`
+	URL            string        `mapstructure:"url"`
+	StreamNames    []string      `mapstructure:"stream_names"`
+	ReadCount      int           `mapstructure:"read_count"`
+	BlockTimeout   time.Duration `mapstructure:"block_timeout"`
+	ConnectionPool PoolConfig    `mapstructure:"connection_pool"`
+	ClusterMode    bool          `mapstructure:"cluster_mode"`
+	ClusterNodes   []string      `mapstructure:"cluster_nodes"`
+	TLS            TLSConfig     `mapstructure:"tls"`
+	Authentication AuthConfig    `mapstructure:"authentication"`
+	// ClaimIdleTimeout is how long a pending message may sit unacknowledged
+	// before another consumer claims it via XCLAIM.
+	ClaimIdleTimeout time.Duration `mapstructure:"claim_idle_timeout"`
 }
 
 // PoolConfig contains connection pool configuration
@@ -84,14 +134,14 @@ type AuthConfig struct {
 
 // DatabaseConfig contains PostgreSQL database configuration
 type DatabaseConfig struct {
-	URL              string        `mapstructure:"url"`
-	MaxOpenConns     int           `mapstructure:"max_open_conns"`
-	MaxIdleConns     int           `mapstructure:"max_idle_conns"`
-	ConnMaxLifetime  time.Duration `mapstructure:"conn_max_lifetime"`
-	ConnMaxIdleTime  time.Duration `mapstructure:"conn_max_idle_time"`
-	Tables           TablesConfig  `mapstructure:"tables"`
-	Migrations       MigrationsConfig `mapstructure:"migrations"`
-	Partitioning     PartitioningConfig `mapstructure:"partitioning"`
+	URL             string             `mapstructure:"url"`
+	MaxOpenConns    int                `mapstructure:"max_open_conns"`
+	MaxIdleConns    int                `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration      `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration      `mapstructure:"conn_max_idle_time"`
+	Tables          TablesConfig       `mapstructure:"tables"`
+	Migrations      MigrationsConfig   `mapstructure:"migrations"`
+	Partitioning    PartitioningConfig `mapstructure:"partitioning"`
 }
 
 // TablesConfig contains table-specific configuration
@@ -120,20 +170,23 @@ type MigrationsConfig struct {
 
 // PartitioningConfig contains table partitioning configuration
 type PartitioningConfig struct {
-	Enabled        bool          `mapstructure:"enabled"`
-	Strategy       string        `mapstructure:"strategy"` // daily, weekly, monthly
-	RetentionDays  int           `mapstructure:"retention_days"`
-	MaintenanceSchedule string   `mapstructure:"maintenance_schedule"`
+	Enabled             bool   `mapstructure:"enabled"`
+	Strategy            string `mapstructure:"strategy"` // daily, weekly, monthly
+	RetentionDays       int    `mapstructure:"retention_days"`
+	MaintenanceSchedule string `mapstructure:"maintenance_schedule"`
 }
 
 // MetricsConfig contains metrics and monitoring configuration
 type MetricsConfig struct {
-	Enabled    bool          `mapstructure:"enabled"`
-	Port       int           `mapstructure:"port"`
-	Path       string        `mapstructure:"path"`
-	Namespace  string        `mapstructure:"namespace"`
-	Prometheus PrometheusConfig `mapstructure:"prometheus"`
+	Enabled    bool                `mapstructure:"enabled"`
+	Port       int                 `mapstructure:"port"`
+	Path       string              `mapstructure:"path"`
+	Namespace  string              `mapstructure:"namespace"`
+	Prometheus PrometheusConfig    `mapstructure:"prometheus"`
 	Custom     CustomMetricsConfig `mapstructure:"custom"`
+	// PprofEnabled exposes net/http/pprof handlers under /debug/pprof on
+	// the same admin listener as /metrics.
+	PprofEnabled bool `mapstructure:"pprof_enabled"`
 }
 
 // PrometheusConfig contains Prometheus-specific configuration
@@ -166,55 +219,55 @@ type MetricCollectorConfig struct {
 
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
-	Level      string          `mapstructure:"level"`
-	Format     string          `mapstructure:"format"` // json, console
-	Output     []string        `mapstructure:"output"` // stdout, stderr, file
-	File       FileLogConfig   `mapstructure:"file"`
-	Structured bool            `mapstructure:"structured"`
-	Sampling   SamplingConfig  `mapstructure:"sampling"`
+	Level      string         `mapstructure:"level"`
+	Format     string         `mapstructure:"format"` // json, console
+	Output     []string       `mapstructure:"output"` // stdout, stderr, file
+	File       FileLogConfig  `mapstructure:"file"`
+	Structured bool           `mapstructure:"structured"`
+	Sampling   SamplingConfig `mapstructure:"sampling"`
 }
 
 // FileLogConfig contains file logging configuration
 type FileLogConfig struct {
 	Path       string `mapstructure:"path"`
-	MaxSize    int    `mapstructure:"max_size"`    // megabytes
+	MaxSize    int    `mapstructure:"max_size"` // megabytes
 	MaxBackups int    `mapstructure:"max_backups"`
-	MaxAge     int    `mapstructure:"max_age"`     // days
+	MaxAge     int    `mapstructure:"max_age"` // days
 	Compress   bool   `mapstructure:"compress"`
 }
 
 // SamplingConfig contains log sampling configuration
 type SamplingConfig struct {
-	Enabled bool `mapstructure:"enabled"`
-	Initial int  `mapstructure:"initial"`
-	Thereafter int `mapstructure:"thereafter"`
+	Enabled    bool `mapstructure:"enabled"`
+	Initial    int  `mapstructure:"initial"`
+	Thereafter int  `mapstructure:"thereafter"`
 }
 
 // Load loads configuration from file
 func Load(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
-	
+
 	// Set defaults
 	setDefaults()
-	
+
 	// Environment variable support
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("PIPELINE")
-	
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-	
+
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
-	
+
 	// Validate configuration
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
-	
+
 	return &config, nil
 }
 
@@ -226,13 +279,13 @@ func setDefaults() {
 	viper.SetDefault("pipeline.batch_timeout", "1s")
 	viper.SetDefault("pipeline.consumer_group_name", "trading_pipeline")
 	viper.SetDefault("pipeline.consumer_name", "consumer_1")
-	
+
 	// Buffer size defaults
 	viper.SetDefault("pipeline.buffer_sizes.stream_consumer", 10000)
 	viper.SetDefault("pipeline.buffer_sizes.worker_pool", 10000)
 	viper.SetDefault("pipeline.buffer_sizes.batch_processor", 5000)
 	viper.SetDefault("pipeline.buffer_sizes.error_channel", 1000)
-	
+
 	// Processing defaults
 	viper.SetDefault("pipeline.processing.max_retries", 3)
 	viper.SetDefault("pipeline.processing.retry_backoff", "1s")
@@ -240,7 +293,7 @@ func setDefaults() {
 	viper.SetDefault("pipeline.processing.validation_enabled", true)
 	viper.SetDefault("pipeline.processing.compression_enabled", false)
 	viper.SetDefault("pipeline.processing.deduplication_window", "5m")
-	
+
 	// Redis defaults
 	viper.SetDefault("redis.url", "redis://localhost:6379")
 	viper.SetDefault("redis.stream_names", []string{"solana_transactions", "solana_accounts"})
@@ -250,30 +303,42 @@ func setDefaults() {
 	viper.SetDefault("redis.connection_pool.max_active", 100)
 	viper.SetDefault("redis.connection_pool.idle_timeout", "300s")
 	viper.SetDefault("redis.connection_pool.wait", true)
-	
+	viper.SetDefault("redis.claim_idle_timeout", "30s")
+
 	// Database defaults
 	viper.SetDefault("database.max_open_conns", 25)
 	viper.SetDefault("database.max_idle_conns", 10)
 	viper.SetDefault("database.conn_max_lifetime", "1h")
 	viper.SetDefault("database.conn_max_idle_time", "15m")
-	
+
 	// Table defaults
 	viper.SetDefault("database.tables.accounts.name", "solana_accounts")
 	viper.SetDefault("database.tables.transactions.name", "solana_transactions")
 	viper.SetDefault("database.tables.blocks.name", "solana_blocks")
 	viper.SetDefault("database.tables.trade_events.name", "trade_events")
-	
+
 	// Partitioning defaults
 	viper.SetDefault("database.partitioning.enabled", true)
 	viper.SetDefault("database.partitioning.strategy", "daily")
 	viper.SetDefault("database.partitioning.retention_days", 30)
-	
+
+	// Sinks defaults
+	viper.SetDefault("sinks.enabled", []string{"postgres"})
+	viper.SetDefault("sinks.clickhouse.batch_size", 10000)
+	viper.SetDefault("sinks.kafka.topic_prefix", "vixen.")
+	viper.SetDefault("sinks.kafka.required_acks", "one")
+	viper.SetDefault("sinks.kafka.max_retries", 3)
+	viper.SetDefault("sinks.kafka.retry_backoff", "500ms")
+	viper.SetDefault("sinks.parquet.slots_per_file", 10000)
+	viper.SetDefault("sinks.parquet.upload.provider", "s3")
+
 	// Metrics defaults
 	viper.SetDefault("metrics.enabled", true)
 	viper.SetDefault("metrics.port", 8080)
 	viper.SetDefault("metrics.path", "/metrics")
 	viper.SetDefault("metrics.namespace", "pipeline")
-	
+	viper.SetDefault("metrics.pprof_enabled", false)
+
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "json")
@@ -287,24 +352,24 @@ func validateConfig(config *Config) error {
 	if config.Pipeline.WorkerPoolSize <= 0 {
 		return fmt.Errorf("pipeline.worker_pool_size must be positive")
 	}
-	
+
 	if config.Pipeline.BatchSize <= 0 {
 		return fmt.Errorf("pipeline.batch_size must be positive")
 	}
-	
+
 	if config.Pipeline.BatchTimeout <= 0 {
 		return fmt.Errorf("pipeline.batch_timeout must be positive")
 	}
-	
+
 	// Validate Redis configuration
 	if len(config.Redis.StreamNames) == 0 {
 		return fmt.Errorf("redis.stream_names cannot be empty")
 	}
-	
+
 	// Validate database configuration
 	if config.Database.URL == "" {
 		return fmt.Errorf("database.url is required")
 	}
-	
+
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Manager wraps a Config with viper.WatchConfig-driven hot reload. Readers
+// call Current for a consistent snapshot; subsystems that need to react to
+// a specific section changing (the worker pool resizing, the logger
+// swapping level, the metrics server rebinding) call the matching Subscribe
+// method for a channel of that section's new value.
+//
+// A reload that fails validateConfig is rejected and logged; the
+// last-known-good config stays live.
+type Manager struct {
+	configPath string
+
+	mu  sync.RWMutex
+	cfg *Config
+
+	subMu        sync.Mutex
+	pipelineSubs []chan PipelineConfig
+	redisSubs    []chan RedisConfig
+	databaseSubs []chan DatabaseConfig
+	metricsSubs  []chan MetricsConfig
+	loggingSubs  []chan LoggingConfig
+}
+
+// NewManager loads configPath and returns a Manager ready to Watch.
+func NewManager(configPath string) (*Manager, error) {
+	cfg, err := Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{configPath: configPath, cfg: cfg}, nil
+}
+
+// Current returns a snapshot of the live configuration. The returned value
+// is a shallow copy: setting its fields has no effect on the Manager, but
+// its slices and maps are shared with it.
+func (m *Manager) Current() *Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshot := *m.cfg
+	return &snapshot
+}
+
+// Watch starts viper's file watcher. On every change it re-reads and
+// re-validates the config, swaps the live snapshot under an RWMutex, and
+// fans out the sections that changed to their subscribers.
+func (m *Manager) Watch() {
+	viper.OnConfigChange(func(_ fsnotify.Event) {
+		m.reload()
+	})
+	viper.WatchConfig()
+}
+
+func (m *Manager) reload() {
+	var next Config
+	if err := viper.Unmarshal(&next); err != nil {
+		log.Printf("config: reload failed to unmarshal, keeping last-known-good config: %v", err)
+		return
+	}
+	if err := validateConfig(&next); err != nil {
+		log.Printf("config: reload rejected, keeping last-known-good config: %v", err)
+		return
+	}
+
+	m.mu.Lock()
+	previous := m.cfg
+	m.cfg = &next
+	m.mu.Unlock()
+
+	logDiff(previous, &next)
+	m.notify(previous, &next)
+}
+
+func (m *Manager) notify(previous, next *Config) {
+	if !reflect.DeepEqual(previous.Pipeline, next.Pipeline) {
+		m.broadcastPipeline(next.Pipeline)
+	}
+	if !reflect.DeepEqual(previous.Redis, next.Redis) {
+		m.broadcastRedis(next.Redis)
+	}
+	if !reflect.DeepEqual(previous.Database, next.Database) {
+		m.broadcastDatabase(next.Database)
+	}
+	if !reflect.DeepEqual(previous.Metrics, next.Metrics) {
+		m.broadcastMetrics(next.Metrics)
+	}
+	if !reflect.DeepEqual(previous.Logging, next.Logging) {
+		m.broadcastLogging(next.Logging)
+	}
+}
+
+func logDiff(previous, next *Config) {
+	if !reflect.DeepEqual(previous.Pipeline, next.Pipeline) {
+		log.Printf("config: pipeline changed: %+v -> %+v", previous.Pipeline, next.Pipeline)
+	}
+	if !reflect.DeepEqual(previous.Redis, next.Redis) {
+		log.Printf("config: redis changed: %+v -> %+v", previous.Redis, next.Redis)
+	}
+	if !reflect.DeepEqual(previous.Database, next.Database) {
+		log.Printf("config: database changed: %+v -> %+v", previous.Database, next.Database)
+	}
+	if !reflect.DeepEqual(previous.Metrics, next.Metrics) {
+		log.Printf("config: metrics changed: %+v -> %+v", previous.Metrics, next.Metrics)
+	}
+	if !reflect.DeepEqual(previous.Logging, next.Logging) {
+		log.Printf("config: logging changed: %+v -> %+v", previous.Logging, next.Logging)
+	}
+}
+
+// SubscribePipeline returns a channel that receives the new PipelineConfig
+// whenever it changes. The channel is buffered by one and only ever holds
+// the latest value; a slow reader never blocks reload.
+func (m *Manager) SubscribePipeline() <-chan PipelineConfig {
+	ch := make(chan PipelineConfig, 1)
+	m.subMu.Lock()
+	m.pipelineSubs = append(m.pipelineSubs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// SubscribeRedis returns a channel that receives the new RedisConfig
+// whenever it changes.
+func (m *Manager) SubscribeRedis() <-chan RedisConfig {
+	ch := make(chan RedisConfig, 1)
+	m.subMu.Lock()
+	m.redisSubs = append(m.redisSubs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// SubscribeDatabase returns a channel that receives the new DatabaseConfig
+// whenever it changes.
+func (m *Manager) SubscribeDatabase() <-chan DatabaseConfig {
+	ch := make(chan DatabaseConfig, 1)
+	m.subMu.Lock()
+	m.databaseSubs = append(m.databaseSubs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// SubscribeMetrics returns a channel that receives the new MetricsConfig
+// whenever it changes.
+func (m *Manager) SubscribeMetrics() <-chan MetricsConfig {
+	ch := make(chan MetricsConfig, 1)
+	m.subMu.Lock()
+	m.metricsSubs = append(m.metricsSubs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// SubscribeLogging returns a channel that receives the new LoggingConfig
+// whenever it changes.
+func (m *Manager) SubscribeLogging() <-chan LoggingConfig {
+	ch := make(chan LoggingConfig, 1)
+	m.subMu.Lock()
+	m.loggingSubs = append(m.loggingSubs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+func (m *Manager) broadcastPipeline(cfg PipelineConfig) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.pipelineSubs {
+		sendLatest(ch, cfg)
+	}
+}
+
+func (m *Manager) broadcastRedis(cfg RedisConfig) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.redisSubs {
+		sendLatest(ch, cfg)
+	}
+}
+
+func (m *Manager) broadcastDatabase(cfg DatabaseConfig) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.databaseSubs {
+		sendLatest(ch, cfg)
+	}
+}
+
+func (m *Manager) broadcastMetrics(cfg MetricsConfig) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.metricsSubs {
+		sendLatest(ch, cfg)
+	}
+}
+
+func (m *Manager) broadcastLogging(cfg LoggingConfig) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.loggingSubs {
+		sendLatest(ch, cfg)
+	}
+}
+
+// sendLatest delivers value to ch, dropping a stale unread value first so
+// subscribers always see the most recent config rather than blocking the
+// reload goroutine.
+func sendLatest[T any](ch chan T, value T) {
+	select {
+	case ch <- value:
+	default:
+		select {
+		case <-ch:
+		default:
+		}
+		select {
+		case ch <- value:
+		default:
+		}
+	}
+}
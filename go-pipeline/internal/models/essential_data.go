@@ -0,0 +1,39 @@
+// Package models contains the data structures shared by the Go pipeline's
+// Redis Streams ingest path and downstream stages.
+package models
+
+// EssentialData mirrors the Rust struct emitted by the stream processor.
+// The Redis Streams consumer decodes each message into this type before
+// handing it to the worker pool; go-pipeline has no SSE ingest path.
+//
+// A single EssentialData describes either an instruction or an account
+// update, matching how go-client's ParsedData flattens the two cases:
+// TransactionSignature, InstructionType and InstructionData are
+// instruction-only, and AccountPubkey, Lamports, and Owner are
+// account-only. AccountPubkey being non-empty is
+// what the batch processor's Handler uses to tell the two apart.
+type EssentialData struct {
+	ProgramID            string                  `json:"program_id"`
+	ProgramName          string                  `json:"program_name,omitempty"`
+	TokenMint            *string                 `json:"token_mint"`
+	TransactionSignature string                  `json:"transaction_signature"`
+	InstructionType      string                  `json:"instruction_type"`
+	InstructionData      map[string]interface{}  `json:"instruction_data"`
+	RawData              []byte                  `json:"raw_data,omitempty"`
+	BlockchainTimestamp  int64                   `json:"blockchain_timestamp"`
+	IngestionTimestamp   int64                   `json:"ingestion_timestamp"`
+	Slot                 uint64                  `json:"slot"`
+	Metadata             *map[string]interface{} `json:"metadata"`
+	// Mode is empty for data arriving from the live ingest paths, or
+	// "replay" when a replay.Runner is re-feeding it from a historical
+	// slot range; the worker pool's Handler defaults an empty Mode to
+	// "live" before it reaches a sink.
+	Mode string `json:"mode,omitempty"`
+
+	// AccountPubkey, Lamports, and Owner are populated instead of the
+	// instruction fields above when this record is an account update
+	// rather than an instruction.
+	AccountPubkey string `json:"account_pubkey,omitempty"`
+	Lamports      uint64 `json:"lamports,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+}
@@ -0,0 +1,231 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// BatchProcessor buffers worker pool output and flushes it to every
+// configured Sink in parallel. Each sink retries independently under
+// ProcessingConfig.MaxRetries/RetryBackoff, so one sink's failure never
+// drops another sink's rows. A flush waits for every sink, retries
+// included, before the next flush starts, so a slow or retrying sink
+// delays later flushes to all sinks.
+type BatchProcessor struct {
+	sinks        []sink.Sink
+	batchSize    int
+	batchTimeout time.Duration
+	retry        config.ProcessingConfig
+	metrics      *metrics.Server
+	log          *zap.Logger
+
+	mu            sync.Mutex
+	buffer        []sink.InstructionRow
+	accountBuffer []sink.AccountRow
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewBatchProcessor creates a BatchProcessor writing through every sink in
+// sinks.
+func NewBatchProcessor(sinks []sink.Sink, batchSize int, batchTimeout time.Duration, retry config.ProcessingConfig, metricsServer *metrics.Server, log *zap.Logger) *BatchProcessor {
+	return &BatchProcessor{
+		sinks:         sinks,
+		batchSize:     batchSize,
+		batchTimeout:  batchTimeout,
+		retry:         retry,
+		metrics:       metricsServer,
+		log:           log,
+		buffer:        make([]sink.InstructionRow, 0, batchSize),
+		accountBuffer: make([]sink.AccountRow, 0, batchSize),
+		flush:         make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start launches the timer-driven flush loop.
+func (b *BatchProcessor) Start(ctx context.Context) {
+	b.wg.Add(1)
+	go b.run(ctx)
+}
+
+func (b *BatchProcessor) run(ctx context.Context) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.batchTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.flushBuffer(context.Background())
+			return
+		case <-b.done:
+			b.flushBuffer(context.Background())
+			return
+		case <-ticker.C:
+			b.flushBuffer(ctx)
+		case <-b.flush:
+			b.flushBuffer(ctx)
+		}
+	}
+}
+
+// Handler returns a Handler suitable for the worker pool: it appends data
+// to the batch - as an AccountRow when data describes an account update,
+// or an InstructionRow otherwise - and triggers an early flush once
+// either batch reaches BatchSize.
+func (b *BatchProcessor) Handler() Handler {
+	return func(ctx context.Context, data *models.EssentialData) error {
+		mode := data.Mode
+		if mode == "" {
+			mode = sink.ModeLive
+		}
+
+		var blockTime *time.Time
+		if data.BlockchainTimestamp != 0 {
+			t := time.Unix(data.BlockchainTimestamp, 0).UTC()
+			blockTime = &t
+		}
+		ingestedAt := time.Unix(data.IngestionTimestamp, 0).UTC()
+
+		b.mu.Lock()
+		if data.AccountPubkey != "" {
+			b.accountBuffer = append(b.accountBuffer, sink.AccountRow{
+				AccountPubkey: data.AccountPubkey,
+				ProgramID:     data.ProgramID,
+				ProgramName:   data.ProgramName,
+				Slot:          data.Slot,
+				BlockTime:     blockTime,
+				IngestedAt:    ingestedAt,
+				RawData:       data.RawData,
+				Lamports:      data.Lamports,
+				Owner:         data.Owner,
+				Mode:          mode,
+			})
+		} else {
+			b.buffer = append(b.buffer, sink.InstructionRow{
+				Signature:       data.TransactionSignature,
+				ProgramID:       data.ProgramID,
+				ProgramName:     data.ProgramName,
+				InstructionType: data.InstructionType,
+				Slot:            data.Slot,
+				BlockTime:       blockTime,
+				IngestedAt:      ingestedAt,
+				RawData:         data.RawData,
+				Mode:            mode,
+			})
+		}
+		full := len(b.buffer) >= b.batchSize || len(b.accountBuffer) >= b.batchSize
+		b.mu.Unlock()
+
+		if full {
+			select {
+			case b.flush <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	}
+}
+
+func (b *BatchProcessor) flushBuffer(ctx context.Context) {
+	b.mu.Lock()
+	if len(b.buffer) == 0 && len(b.accountBuffer) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	rows := b.buffer
+	accounts := b.accountBuffer
+	b.buffer = make([]sink.InstructionRow, 0, b.batchSize)
+	b.accountBuffer = make([]sink.AccountRow, 0, b.batchSize)
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(len(b.sinks))
+	for _, s := range b.sinks {
+		s := s
+		go func() {
+			defer wg.Done()
+			if len(accounts) > 0 {
+				b.writeWithRetry(ctx, s, len(accounts), func(ctx context.Context) error {
+					return s.WriteAccounts(ctx, accounts)
+				})
+			}
+			if len(rows) > 0 {
+				b.writeWithRetry(ctx, s, len(rows), func(ctx context.Context) error {
+					return s.WriteInstructions(ctx, rows)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// writeWithRetry calls write, retrying up to ProcessingConfig.MaxRetries
+// times with RetryBackoff between attempts. A sink that exhausts its
+// retries only drops its own batch, but flushBuffer waits for it, so its
+// retries delay the next flush to every sink.
+// rowCount is used only for logging and metrics.
+func (b *BatchProcessor) writeWithRetry(ctx context.Context, s sink.Sink, rowCount int, write func(context.Context) error) {
+	start := time.Now()
+
+	var err error
+	for attempt := 0; attempt <= b.retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(b.retry.RetryBackoff):
+			}
+		}
+
+		err = write(ctx)
+		if err == nil {
+			break
+		}
+		b.log.Warn("sink write failed, retrying",
+			zap.String("sink", s.Name()),
+			zap.Int("attempt", attempt+1),
+			zap.Error(err),
+		)
+	}
+
+	elapsed := time.Since(start)
+	if b.metrics != nil {
+		b.metrics.BatchSize.WithLabelValues(s.Name()).Observe(float64(rowCount))
+		b.metrics.BatchFlushLatency.WithLabelValues(s.Name()).Observe(elapsed.Seconds())
+		if s.Name() == "postgres" {
+			b.metrics.PostgresInsertDuration.WithLabelValues(s.Name()).Observe(elapsed.Seconds())
+		}
+	}
+
+	if err != nil {
+		if b.metrics != nil {
+			b.metrics.SinkWriteErrors.WithLabelValues(s.Name()).Inc()
+		}
+		b.log.Error("failed to flush batch to sink", zap.String("sink", s.Name()), zap.Int("rows", rowCount), zap.Error(err))
+	}
+}
+
+// Stop flushes any remaining buffered rows, waits for the flush loop to
+// exit, and closes every sink.
+func (b *BatchProcessor) Stop() {
+	close(b.done)
+	b.wg.Wait()
+
+	for _, s := range b.sinks {
+		if err := s.Close(); err != nil {
+			b.log.Error("failed to close sink", zap.String("sink", s.Name()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// fakeSink records every row it's given, for asserting what the batch
+// processor actually flushed.
+type fakeSink struct {
+	mu          sync.Mutex
+	accountRows []sink.AccountRow
+	instrRows   []sink.InstructionRow
+}
+
+func (f *fakeSink) Name() string { return "fake" }
+
+func (f *fakeSink) WriteAccounts(ctx context.Context, rows []sink.AccountRow) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accountRows = append(f.accountRows, rows...)
+	return nil
+}
+
+func (f *fakeSink) WriteInstructions(ctx context.Context, rows []sink.InstructionRow) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.instrRows = append(f.instrRows, rows...)
+	return nil
+}
+
+func (f *fakeSink) Flush(ctx context.Context) error { return nil }
+func (f *fakeSink) Close() error                    { return nil }
+
+func newTestBatchProcessor(s *fakeSink) *BatchProcessor {
+	return NewBatchProcessor([]sink.Sink{s}, 100, time.Hour, config.ProcessingConfig{MaxRetries: 0}, nil, zap.NewNop())
+}
+
+func TestHandlerWritesInstructionRowForInstructionData(t *testing.T) {
+	s := &fakeSink{}
+	b := newTestBatchProcessor(s)
+	handler := b.Handler()
+
+	data := &models.EssentialData{
+		ProgramID:            "Program1111111111111111111111111111111111",
+		TransactionSignature: "sig-1",
+		InstructionType:      "swap",
+		Slot:                 100,
+	}
+	if err := handler(context.Background(), data); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	b.flushBuffer(context.Background())
+
+	if len(s.instrRows) != 1 {
+		t.Fatalf("got %d instruction rows, want 1", len(s.instrRows))
+	}
+	if len(s.accountRows) != 0 {
+		t.Fatalf("got %d account rows, want 0", len(s.accountRows))
+	}
+	if s.instrRows[0].Signature != "sig-1" || s.instrRows[0].Mode != sink.ModeLive {
+		t.Fatalf("unexpected instruction row: %+v", s.instrRows[0])
+	}
+}
+
+func TestHandlerWritesAccountRowForAccountData(t *testing.T) {
+	s := &fakeSink{}
+	b := newTestBatchProcessor(s)
+	handler := b.Handler()
+
+	data := &models.EssentialData{
+		ProgramID:     "Program1111111111111111111111111111111111",
+		AccountPubkey: "Account11111111111111111111111111111111111",
+		Slot:          100,
+		Lamports:      5000,
+		Owner:         "Owner111111111111111111111111111111111111",
+	}
+	if err := handler(context.Background(), data); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	b.flushBuffer(context.Background())
+
+	if len(s.accountRows) != 1 {
+		t.Fatalf("got %d account rows, want 1", len(s.accountRows))
+	}
+	if len(s.instrRows) != 0 {
+		t.Fatalf("got %d instruction rows, want 0", len(s.instrRows))
+	}
+	row := s.accountRows[0]
+	if row.AccountPubkey != "Account11111111111111111111111111111111111" || row.Lamports != 5000 || row.Owner != "Owner111111111111111111111111111111111111" {
+		t.Fatalf("unexpected account row: %+v", row)
+	}
+}
+
+func TestHandlerPopulatesProgramNameRawDataAndTimestamps(t *testing.T) {
+	s := &fakeSink{}
+	b := newTestBatchProcessor(s)
+	handler := b.Handler()
+
+	blockTime := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	ingestedAt := time.Date(2026, 7, 1, 0, 0, 5, 0, time.UTC)
+
+	data := &models.EssentialData{
+		ProgramID:            "Program1111111111111111111111111111111111",
+		ProgramName:          "Pump.fun AMM",
+		TransactionSignature: "sig-1",
+		InstructionType:      "swap",
+		RawData:              []byte(`{"foo":"bar"}`),
+		BlockchainTimestamp:  blockTime.Unix(),
+		IngestionTimestamp:   ingestedAt.Unix(),
+		Slot:                 100,
+	}
+	if err := handler(context.Background(), data); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	b.flushBuffer(context.Background())
+
+	if len(s.instrRows) != 1 {
+		t.Fatalf("got %d instruction rows, want 1", len(s.instrRows))
+	}
+	row := s.instrRows[0]
+	if row.ProgramName != "Pump.fun AMM" {
+		t.Fatalf("ProgramName = %q, want %q", row.ProgramName, "Pump.fun AMM")
+	}
+	if string(row.RawData) != `{"foo":"bar"}` {
+		t.Fatalf("RawData = %q, want %q", row.RawData, `{"foo":"bar"}`)
+	}
+	if row.BlockTime == nil || !row.BlockTime.Equal(blockTime) {
+		t.Fatalf("BlockTime = %v, want %v", row.BlockTime, blockTime)
+	}
+	if !row.IngestedAt.Equal(ingestedAt) {
+		t.Fatalf("IngestedAt = %v, want %v", row.IngestedAt, ingestedAt)
+	}
+}
+
+func TestHandlerBatchesBothKindsInOneFlush(t *testing.T) {
+	s := &fakeSink{}
+	b := newTestBatchProcessor(s)
+	handler := b.Handler()
+
+	if err := handler(context.Background(), &models.EssentialData{TransactionSignature: "sig-1", InstructionType: "swap", Slot: 1}); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if err := handler(context.Background(), &models.EssentialData{AccountPubkey: "Account11111111111111111111111111111111111", Slot: 1}); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	b.flushBuffer(context.Background())
+
+	if len(s.instrRows) != 1 || len(s.accountRows) != 1 {
+		t.Fatalf("got %d instruction rows and %d account rows, want 1 and 1", len(s.instrRows), len(s.accountRows))
+	}
+}
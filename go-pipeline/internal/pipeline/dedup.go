@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/dedup"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// dedupReporter turns filter.Stats' running totals into increments for
+// metricsServer.DedupHits: Stats returns cumulative counts, so each report
+// adds only what has grown since the previous one.
+type dedupReporter struct {
+	mu   sync.Mutex
+	last dedup.Stats
+}
+
+// report diffs filter's current Stats against the last snapshot reported
+// and adds the difference to metricsServer.DedupHits, labeled by result.
+func (r *dedupReporter) report(filter *dedup.Filter, metricsServer *metrics.Server) {
+	stats := filter.Stats()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d := stats.FilterHits - r.last.FilterHits; d > 0 {
+		metricsServer.DedupHits.WithLabelValues("bloom_hit").Add(float64(d))
+	}
+	if d := stats.ConfirmedDupes - r.last.ConfirmedDupes; d > 0 {
+		metricsServer.DedupHits.WithLabelValues("confirmed_dupe").Add(float64(d))
+	}
+	if d := stats.FalsePositiveConfirmations - r.last.FalsePositiveConfirmations; d > 0 {
+		metricsServer.DedupHits.WithLabelValues("false_positive").Add(float64(d))
+	}
+	if d := stats.CrossReplicaDupes - r.last.CrossReplicaDupes; d > 0 {
+		metricsServer.DedupHits.WithLabelValues("cross_replica_dupe").Add(float64(d))
+	}
+	r.last = stats
+}
+
+// WithDedup wraps a Handler so that items already seen within
+// ProcessingConfig.DeduplicationWindow are dropped before reaching next,
+// the same place handleInstructionEvent used to call straight into
+// processInstruction. Every call also reports filter's latest Stats into
+// metricsServer.DedupHits, so dashboards don't need a separate poller.
+func WithDedup(filter *dedup.Filter, metricsServer *metrics.Server, log *zap.Logger, next Handler) Handler {
+	reporter := &dedupReporter{}
+	return func(ctx context.Context, data *models.EssentialData) error {
+		dupe, err := filter.Seen(ctx, data)
+		if metricsServer != nil {
+			reporter.report(filter, metricsServer)
+		}
+		if err != nil {
+			log.Warn("dedup check failed, processing item anyway",
+				zap.String("signature", data.TransactionSignature), zap.Error(err))
+		} else if dupe {
+			log.Debug("dropping duplicate item",
+				zap.String("signature", data.TransactionSignature),
+				zap.Uint64("slot", data.Slot),
+			)
+			return nil
+		}
+		return next(ctx, data)
+	}
+}
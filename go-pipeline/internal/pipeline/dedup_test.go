@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/dedup"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func newTestDedupFilter(t *testing.T) *dedup.Filter {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return dedup.New(time.Minute, client)
+}
+
+func TestWithDedupReportsStatsToMetrics(t *testing.T) {
+	filter := newTestDedupFilter(t)
+	metricsServer := metrics.NewServer(config.MetricsConfig{Namespace: "test_dedup"})
+
+	var calls int
+	next := func(ctx context.Context, data *models.EssentialData) error {
+		calls++
+		return nil
+	}
+	handler := WithDedup(filter, metricsServer, zap.NewNop(), next)
+
+	data := &models.EssentialData{TransactionSignature: "sig-1", InstructionType: "swap", Slot: 1}
+	if err := handler(context.Background(), data); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if err := handler(context.Background(), data); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("next was called %d times, want 1 (the duplicate should have been dropped)", calls)
+	}
+	if got := testutil.ToFloat64(metricsServer.DedupHits.WithLabelValues("confirmed_dupe")); got != 1 {
+		t.Fatalf("DedupHits{result=confirmed_dupe} = %v, want 1", got)
+	}
+}
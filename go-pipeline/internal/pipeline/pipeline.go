@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/dedup"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/streams"
+	"go.uber.org/zap"
+)
+
+// Pipeline wires together the stages the package doc (worker_pool.go)
+// describes: a
+// Redis Streams Consumer dispatches into a WorkerPool, which runs a
+// dedup-wrapped BatchProcessor.Handler backed by every sink BuildSinks
+// constructs from Config.Sinks.
+type Pipeline struct {
+	consumer *streams.Consumer
+	workers  *WorkerPool
+	batch    *BatchProcessor
+	dedup    *dedup.Filter
+
+	dedupCancel context.CancelFunc
+	log         *zap.Logger
+}
+
+// New builds every pipeline stage from cfg, wiring metricsServer into each
+// one that reports metrics. It does not start any of them; call Run to
+// begin processing.
+func New(cfg *config.Config, log *zap.Logger, metricsServer *metrics.Server) (*Pipeline, error) {
+	ctx := context.Background()
+
+	sinks, err := BuildSinks(ctx, cfg.Sinks, cfg.Database, log)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: failed to build sinks: %w", err)
+	}
+
+	redisClient, err := streams.NewRedisClient(cfg.Redis)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: failed to build redis client: %w", err)
+	}
+	dedupFilter := dedup.New(cfg.Pipeline.Processing.DeduplicationWindow, redisClient)
+
+	batch := NewBatchProcessor(sinks, cfg.Pipeline.BatchSize, cfg.Pipeline.BatchTimeout, cfg.Pipeline.Processing, metricsServer, log)
+	handler := WithDedup(dedupFilter, metricsServer, log, batch.Handler())
+	workers := NewWorkerPool(cfg.Pipeline.WorkerPoolSize, cfg.Pipeline.BufferSizes.WorkerPool, handler, metricsServer, log)
+
+	consumer, err := streams.NewConsumer(cfg.Redis, cfg.Pipeline, workers.Submit, metricsServer, log)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: failed to build redis streams consumer: %w", err)
+	}
+
+	return &Pipeline{
+		consumer: consumer,
+		workers:  workers,
+		batch:    batch,
+		dedup:    dedupFilter,
+		log:      log,
+	}, nil
+}
+
+// ResizeWorkerPool changes the number of running worker goroutines, the
+// pipeline's reaction to PipelineConfig.WorkerPoolSize changing under
+// config hot-reload.
+func (p *Pipeline) ResizeWorkerPool(size int) {
+	p.workers.Resize(size)
+}
+
+// Run starts every stage and blocks on the Redis Streams consumer until ctx
+// is cancelled.
+func (p *Pipeline) Run(ctx context.Context) error {
+	dedupCtx, cancel := context.WithCancel(ctx)
+	p.dedupCancel = cancel
+	go p.dedup.Run(dedupCtx)
+
+	p.batch.Start(ctx)
+	p.workers.Start(ctx)
+
+	return p.consumer.Run(ctx)
+}
+
+// Shutdown stops the dedup rotation, then closes the consumer, stops the
+// worker pool and finally the batch processor, so in-flight items drain
+// through the worker pool and batch processor before their sinks close.
+func (p *Pipeline) Shutdown(ctx context.Context) error {
+	if p.dedupCancel != nil {
+		p.dedupCancel()
+	}
+	if err := p.consumer.Close(); err != nil {
+		p.log.Error("failed to close redis streams consumer", zap.Error(err))
+	}
+	p.workers.Stop()
+	p.batch.Stop()
+	return nil
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink/clickhouse"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink/kafka"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink/parquet"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink/postgres"
+	"go.uber.org/zap"
+)
+
+// BuildSinks constructs one sink.Sink per entry in cfg.Enabled. databaseCfg
+// is only consulted for the "postgres" entry, since it's the only sink
+// backed by DatabaseConfig rather than its own section of SinksConfig.
+func BuildSinks(ctx context.Context, cfg config.SinksConfig, databaseCfg config.DatabaseConfig, log *zap.Logger) ([]sink.Sink, error) {
+	sinks := make([]sink.Sink, 0, len(cfg.Enabled))
+
+	for _, name := range cfg.Enabled {
+		switch name {
+		case "postgres":
+			s, err := postgres.New(ctx, databaseCfg, log)
+			if err != nil {
+				return nil, fmt.Errorf("pipeline: failed to build postgres sink: %w", err)
+			}
+			sinks = append(sinks, s)
+
+		case "clickhouse":
+			s, err := clickhouse.New(ctx, cfg.ClickHouse, log)
+			if err != nil {
+				return nil, fmt.Errorf("pipeline: failed to build clickhouse sink: %w", err)
+			}
+			sinks = append(sinks, s)
+
+		case "kafka":
+			sinks = append(sinks, kafka.New(cfg.Kafka, log))
+
+		case "parquet":
+			uploader, err := parquetUploader(ctx, cfg.Parquet)
+			if err != nil {
+				return nil, fmt.Errorf("pipeline: failed to build parquet uploader: %w", err)
+			}
+			sinks = append(sinks, parquet.New(cfg.Parquet, uploader, log))
+
+		default:
+			return nil, fmt.Errorf("pipeline: unknown sink %q", name)
+		}
+	}
+
+	return sinks, nil
+}
+
+func parquetUploader(ctx context.Context, cfg config.ParquetConfig) (parquet.Uploader, error) {
+	switch cfg.Upload.Provider {
+	case "s3", "":
+		return parquet.NewS3Uploader(ctx, cfg.Upload.Region, cfg.Upload.Bucket)
+	default:
+		return nil, fmt.Errorf("pipeline: unsupported parquet upload provider %q", cfg.Upload.Provider)
+	}
+}
@@ -0,0 +1,180 @@
+// Package pipeline wires the pipeline's ingest, processing and sink stages
+// together: Redis Stream Consumer → Worker Pool → Batch Processor →
+// Sinks (PostgreSQL, ClickHouse, Kafka, Parquet-on-S3, selected by
+// SinksConfig.Enabled and run in parallel). There is no SSE ingest path
+// and no GCS uploader.
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Handler processes a single EssentialData item. It is invoked from a
+// worker goroutine and must be safe to call concurrently.
+type Handler func(ctx context.Context, data *models.EssentialData) error
+
+// job pairs an item with the ack callback its consumer needs called once
+// processing finishes, so the ingest path (Redis XACK) stays
+// decoupled from how the item is actually processed.
+type job struct {
+	data *models.EssentialData
+	ack  func(error)
+}
+
+// WorkerPool fans incoming items out across a pool of goroutines, sized by
+// PipelineConfig.WorkerPoolSize and adjustable at runtime via Resize.
+type WorkerPool struct {
+	handler Handler
+	log     *zap.Logger
+	metrics *metrics.Server
+
+	jobs chan job
+	wg   sync.WaitGroup
+
+	mu      sync.Mutex
+	ctx     context.Context
+	workers []chan struct{}
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// NewWorkerPool creates a worker pool with the given number of workers and
+// job buffer size. metricsServer may be nil, in which case stage timings
+// simply aren't recorded.
+func NewWorkerPool(size, bufferSize int, handler Handler, metricsServer *metrics.Server, log *zap.Logger) *WorkerPool {
+	return &WorkerPool{
+		handler: handler,
+		log:     log,
+		metrics: metricsServer,
+		jobs:    make(chan job, bufferSize),
+		workers: make([]chan struct{}, 0, size),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start launches the initial worker goroutines. It returns immediately;
+// workers run until the context is cancelled, Stop is called, or Resize
+// tells an individual worker to exit.
+func (p *WorkerPool) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.ctx = ctx
+	size := cap(p.workers)
+	p.mu.Unlock()
+
+	for i := 0; i < size; i++ {
+		p.startWorker(ctx)
+	}
+}
+
+func (p *WorkerPool) startWorker(ctx context.Context) {
+	quit := make(chan struct{})
+
+	p.mu.Lock()
+	id := len(p.workers)
+	p.workers = append(p.workers, quit)
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go p.worker(ctx, id, quit)
+}
+
+// Resize grows or shrinks the running worker count to size. Growing starts
+// additional workers immediately; shrinking signals the newest workers to
+// exit once they finish any job already in flight. It is the pipeline's
+// reaction to PipelineConfig.WorkerPoolSize changing under config
+// hot-reload.
+func (p *WorkerPool) Resize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	ctx := p.ctx
+	current := len(p.workers)
+	var toStop []chan struct{}
+	if size < current {
+		toStop = p.workers[size:]
+		p.workers = p.workers[:size]
+	}
+	p.mu.Unlock()
+
+	if ctx == nil {
+		return
+	}
+
+	for _, quit := range toStop {
+		close(quit)
+	}
+	for i := current; i < size; i++ {
+		p.startWorker(ctx)
+	}
+}
+
+func (p *WorkerPool) worker(ctx context.Context, id int, quit chan struct{}) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopped:
+			return
+		case <-quit:
+			return
+		case j, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			if p.metrics != nil {
+				p.metrics.WorkerQueueDepth.Dec()
+				label := metrics.SafeProgramLabel(j.data.ProgramID)
+				if j.data.AccountPubkey != "" {
+					p.metrics.AccountsIngested.WithLabelValues(label).Inc()
+				} else {
+					p.metrics.InstructionsIngested.WithLabelValues(label).Inc()
+				}
+			}
+			err := p.handler(ctx, j.data)
+			if err != nil {
+				p.log.Error("worker failed to process item",
+					zap.Int("worker_id", id),
+					zap.String("signature", j.data.TransactionSignature),
+					zap.Error(err),
+				)
+			}
+			if j.ack != nil {
+				j.ack(err)
+			}
+		}
+	}
+}
+
+// Submit enqueues an item for processing, blocking until there is room in
+// the job buffer or ctx is cancelled. ack, if non-nil, is invoked with the
+// handler's error (or nil on success) once the item has been processed.
+func (p *WorkerPool) Submit(ctx context.Context, data *models.EssentialData, ack func(error)) error {
+	select {
+	case p.jobs <- job{data: data, ack: ack}:
+		if p.metrics != nil {
+			p.metrics.WorkerQueueDepth.Inc()
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop signals all workers to exit and waits for them to drain in-flight
+// work.
+func (p *WorkerPool) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopped)
+	})
+	p.wg.Wait()
+}
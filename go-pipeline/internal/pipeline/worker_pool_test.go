@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func TestWorkerPoolCountsAccountsAndInstructionsSeparately(t *testing.T) {
+	metricsServer := metrics.NewServer(config.MetricsConfig{Namespace: "test_worker_pool"})
+
+	var processed int
+	handler := func(ctx context.Context, data *models.EssentialData) error {
+		processed++
+		return nil
+	}
+
+	pool := NewWorkerPool(1, 4, handler, metricsServer, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	done := make(chan error, 2)
+	if err := pool.Submit(ctx, &models.EssentialData{ProgramID: "Program1111111111111111111111111111111111", AccountPubkey: "Account11111111111111111111111111111111111"}, func(err error) { done <- err }); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if err := pool.Submit(ctx, &models.EssentialData{ProgramID: "Program1111111111111111111111111111111111", TransactionSignature: "sig-1"}, func(err error) { done <- err }); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for jobs to process")
+		}
+	}
+
+	if got := testutil.ToFloat64(metricsServer.AccountsIngested.WithLabelValues(metrics.SafeProgramLabel("Program1111111111111111111111111111111111"))); got != 1 {
+		t.Fatalf("AccountsIngested = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metricsServer.InstructionsIngested.WithLabelValues(metrics.SafeProgramLabel("Program1111111111111111111111111111111111"))); got != 1 {
+		t.Fatalf("InstructionsIngested = %v, want 1", got)
+	}
+}
+
+func TestWorkerPoolResizeGrowsAndShrinksWorkers(t *testing.T) {
+	handler := func(ctx context.Context, data *models.EssentialData) error { return nil }
+
+	pool := NewWorkerPool(2, 4, handler, nil, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	if got := len(pool.workers); got != 2 {
+		t.Fatalf("workers after Start = %d, want 2", got)
+	}
+
+	pool.Resize(5)
+	if got := len(pool.workers); got != 5 {
+		t.Fatalf("workers after Resize(5) = %d, want 5", got)
+	}
+
+	pool.Resize(1)
+	if got := len(pool.workers); got != 1 {
+		t.Fatalf("workers after Resize(1) = %d, want 1", got)
+	}
+
+	// A job submitted after shrinking still gets processed by whichever
+	// worker is left running.
+	done := make(chan error, 1)
+	if err := pool.Submit(ctx, &models.EssentialData{TransactionSignature: "sig-1"}, func(err error) { done <- err }); err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for job to process after resize")
+	}
+}
@@ -0,0 +1,58 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/pipeline"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// adminRequest is the SlotRangeQuery-shaped body POST /admin/replay takes.
+type adminRequest struct {
+	MinSlot  uint64 `json:"min_slot"`
+	MaxSlot  uint64 `json:"max_slot"`
+	Consumer string `json:"consumer"`
+}
+
+// NewAdminHandler returns the handler for the replay admin endpoint:
+// POST a SlotRangeQuery-shaped JSON body and it runs synchronously,
+// responding with the outcome, so an operator triggering a backfill gets
+// a pass/fail answer in the response rather than having to poll a
+// separate job-status endpoint.
+func NewAdminHandler(reader sink.Reader, handler pipeline.Handler, offsets *OffsetStore, log *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "replay: only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body adminRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(w, fmt.Sprintf("replay: invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if body.MaxSlot < body.MinSlot {
+			http.Error(w, "replay: max_slot must be >= min_slot", http.StatusBadRequest)
+			return
+		}
+		if body.Consumer == "" {
+			body.Consumer = "admin"
+		}
+
+		runner := NewRunner(body.Consumer, reader, handler, offsets, log)
+		if err := runner.Run(req.Context(), SlotRangeQuery{MinSlot: body.MinSlot, MaxSlot: body.MaxSlot}); err != nil {
+			http.Error(w, fmt.Sprintf("replay: run failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":   "completed",
+			"min_slot": body.MinSlot,
+			"max_slot": body.MaxSlot,
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package replay
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var offsetsBucket = []byte("replay_offsets")
+
+// OffsetStore persists, per consumer name, the last slot a replay run
+// committed, in a small embedded BoltDB file. A crash mid-replay resumes
+// Run just after the last committed slot instead of reprocessing the whole
+// min-slot/max-slot window from scratch.
+type OffsetStore struct {
+	db *bbolt.DB
+}
+
+// OpenOffsetStore opens the BoltDB file at path, creating it and its
+// bucket if this is the first run.
+func OpenOffsetStore(path string) (*OffsetStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("replay: failed to open offset store %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(offsetsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("replay: failed to initialize offset store %s: %w", path, err)
+	}
+
+	return &OffsetStore{db: db}, nil
+}
+
+// Committed returns the last slot committed for consumer, and false if
+// consumer has never committed one.
+func (o *OffsetStore) Committed(consumer string) (slot uint64, ok bool, err error) {
+	err = o.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(offsetsBucket).Get([]byte(consumer))
+		if v == nil {
+			return nil
+		}
+		slot = binary.BigEndian.Uint64(v)
+		ok = true
+		return nil
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("replay: failed to read offset for %q: %w", consumer, err)
+	}
+	return slot, ok, nil
+}
+
+// Commit records slot as the last slot consumer has fully processed.
+func (o *OffsetStore) Commit(consumer string, slot uint64) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, slot)
+
+	if err := o.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(offsetsBucket).Put([]byte(consumer), buf)
+	}); err != nil {
+		return fmt.Errorf("replay: failed to commit offset %d for %q: %w", slot, consumer, err)
+	}
+	return nil
+}
+
+// Close closes the underlying BoltDB file.
+func (o *OffsetStore) Close() error {
+	return o.db.Close()
+}
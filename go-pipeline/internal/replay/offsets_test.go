@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestOffsetStore(t *testing.T) *OffsetStore {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "replay-offsets.db")
+	store, err := OpenOffsetStore(path)
+	if err != nil {
+		t.Fatalf("OpenOffsetStore failed: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestCommittedReturnsNotOKForAnUnseenConsumer(t *testing.T) {
+	store := openTestOffsetStore(t)
+
+	_, ok, err := store.Committed("clickhouse-backfill")
+	if err != nil {
+		t.Fatalf("Committed returned error: %v", err)
+	}
+	if ok {
+		t.Fatal("Committed reported ok for a consumer that has never committed")
+	}
+}
+
+func TestCommitThenCommittedRoundTrips(t *testing.T) {
+	store := openTestOffsetStore(t)
+
+	if err := store.Commit("clickhouse-backfill", 12345); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	slot, ok, err := store.Committed("clickhouse-backfill")
+	if err != nil {
+		t.Fatalf("Committed returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("Committed reported not ok after a successful Commit")
+	}
+	if slot != 12345 {
+		t.Fatalf("Committed slot = %d, want 12345", slot)
+	}
+}
+
+func TestCommitOverwritesThePreviousSlot(t *testing.T) {
+	store := openTestOffsetStore(t)
+
+	if err := store.Commit("clickhouse-backfill", 100); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := store.Commit("clickhouse-backfill", 200); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	slot, _, err := store.Committed("clickhouse-backfill")
+	if err != nil {
+		t.Fatalf("Committed returned error: %v", err)
+	}
+	if slot != 200 {
+		t.Fatalf("Committed slot = %d, want 200 after a second Commit", slot)
+	}
+}
+
+func TestCommittedOffsetsAreIsolatedByConsumer(t *testing.T) {
+	store := openTestOffsetStore(t)
+
+	if err := store.Commit("clickhouse-backfill", 100); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := store.Commit("postgres-backfill", 500); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	slot, _, err := store.Committed("clickhouse-backfill")
+	if err != nil {
+		t.Fatalf("Committed returned error: %v", err)
+	}
+	if slot != 100 {
+		t.Fatalf("clickhouse-backfill committed slot = %d, want 100 (unaffected by postgres-backfill's commit)", slot)
+	}
+}
@@ -0,0 +1,123 @@
+// Package replay rewinds processing to a historical (min_slot, max_slot)
+// window, reading rows back out of a sink.Reader and re-feeding them
+// through the worker pool's write path exactly as if they'd arrived live,
+// except tagged sink.ModeReplay so operators can diff-check a backfill
+// before trusting it. This is what makes reprocessing after a decoder bug
+// fix, or backfilling a program added mid-flight, possible without
+// replaying from the Solana stream processor itself.
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/pipeline"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+// SlotRangeQuery selects the slot window a replay run covers, mirroring
+// the (min_slot, max_slot) shape go-client's SlotRangeQuery exposes to API
+// callers.
+type SlotRangeQuery struct {
+	MinSlot uint64
+	MaxSlot uint64
+}
+
+// Runner reads a slot range out of a sink.Reader and re-feeds it through
+// the worker pool's Handler, committing each slot to an OffsetStore once
+// all of its rows have been handed to the Handler.
+type Runner struct {
+	consumerName string
+	reader       sink.Reader
+	handler      pipeline.Handler
+	offsets      *OffsetStore
+	log          *zap.Logger
+}
+
+// NewRunner builds a Runner. handler is typically
+// (*pipeline.BatchProcessor).Handler(), so replayed rows flow through the
+// exact write path live rows do - the same batching, the same retries,
+// the same sinks. consumerName namespaces this Runner's committed offset
+// in the OffsetStore, so two replay runs covering different windows (or
+// reading from different sinks) don't clobber each other's progress.
+func NewRunner(consumerName string, reader sink.Reader, handler pipeline.Handler, offsets *OffsetStore, log *zap.Logger) *Runner {
+	return &Runner{
+		consumerName: consumerName,
+		reader:       reader,
+		handler:      handler,
+		offsets:      offsets,
+		log:          log,
+	}
+}
+
+// Run replays query's slot range, resuming after consumerName's last
+// committed slot when this consumer has already replayed part of the
+// window.
+func (r *Runner) Run(ctx context.Context, query SlotRangeQuery) error {
+	minSlot := query.MinSlot
+	committed, ok, err := r.offsets.Committed(r.consumerName)
+	if err != nil {
+		return err
+	}
+	if ok && committed+1 > minSlot {
+		minSlot = committed + 1
+	}
+	if minSlot > query.MaxSlot {
+		r.log.Info("replay: nothing left to replay",
+			zap.String("consumer", r.consumerName),
+			zap.Uint64("min_slot", query.MinSlot),
+			zap.Uint64("max_slot", query.MaxSlot),
+		)
+		return nil
+	}
+
+	rows, errs := r.reader.ReadInstructions(ctx, minSlot, query.MaxSlot)
+	// Rows arrive oldest slot first, so a slot is committed only once a
+	// row from a later slot shows up (or the read completes), never part
+	// way through it.
+	var pendingSlot uint64
+	havePending := false
+	for row := range rows {
+		if havePending && row.Slot != pendingSlot {
+			if err := r.offsets.Commit(r.consumerName, pendingSlot); err != nil {
+				return fmt.Errorf("replay: failed to commit offset at slot %d: %w", pendingSlot, err)
+			}
+		}
+		data := &models.EssentialData{
+			ProgramID:            row.ProgramID,
+			ProgramName:          row.ProgramName,
+			TransactionSignature: row.Signature,
+			InstructionType:      row.InstructionType,
+			RawData:              row.RawData,
+			IngestionTimestamp:   row.IngestedAt.Unix(),
+			Slot:                 row.Slot,
+			Mode:                 sink.ModeReplay,
+		}
+		if row.BlockTime != nil {
+			data.BlockchainTimestamp = row.BlockTime.Unix()
+		}
+
+		if err := r.handler(ctx, data); err != nil {
+			return fmt.Errorf("replay: handler failed at slot %d: %w", row.Slot, err)
+		}
+		pendingSlot, havePending = row.Slot, true
+	}
+
+	if err := <-errs; err != nil {
+		return fmt.Errorf("replay: read failed: %w", err)
+	}
+	if havePending && ctx.Err() == nil {
+		if err := r.offsets.Commit(r.consumerName, pendingSlot); err != nil {
+			return fmt.Errorf("replay: failed to commit offset at slot %d: %w", pendingSlot, err)
+		}
+	}
+
+	r.log.Info("replay: window complete",
+		zap.String("consumer", r.consumerName),
+		zap.Uint64("min_slot", minSlot),
+		zap.Uint64("max_slot", query.MaxSlot),
+	)
+	return nil
+}
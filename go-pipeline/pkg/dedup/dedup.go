@@ -0,0 +1,152 @@
+// Package dedup enforces ProcessingConfig.DeduplicationWindow so duplicate
+// deliveries from the Redis Streams consumer don't reach the sinks twice.
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultExpectedItems     = 1_000_000
+	defaultFalsePositiveRate = 0.001
+)
+
+// Stats holds the running counters for a Filter, useful for exposing as
+// Prometheus metrics.
+type Stats struct {
+	FilterHits                 uint64
+	ConfirmedDupes             uint64
+	FalsePositiveConfirmations uint64
+	// CrossReplicaDupes counts keys Redis confirmed as duplicates despite
+	// missing both local Bloom filters, i.e. duplicates only another
+	// replica's confirm call could have caught.
+	CrossReplicaDupes uint64
+}
+
+// Filter deduplicates on (TransactionSignature, InstructionType, Slot) using
+// two rotating in-process Bloom filters covering DeduplicationWindow, with
+// Redis SET NX as a cross-replica source of truth that every key is
+// confirmed against.
+type Filter struct {
+	window time.Duration
+	redis  redis.UniversalClient
+
+	mu      sync.RWMutex
+	current *bloom.BloomFilter
+	older   *bloom.BloomFilter
+
+	filterHits                 atomic.Uint64
+	confirmedDupes             atomic.Uint64
+	falsePositiveConfirmations atomic.Uint64
+	crossReplicaDupes          atomic.Uint64
+}
+
+// New creates a Filter whose Bloom filters are each sized for
+// defaultExpectedItems at defaultFalsePositiveRate; Run swaps them every
+// window/2.
+func New(window time.Duration, redisClient redis.UniversalClient) *Filter {
+	return &Filter{
+		window:  window,
+		redis:   redisClient,
+		current: bloom.NewWithEstimates(defaultExpectedItems, defaultFalsePositiveRate),
+		older:   bloom.NewWithEstimates(defaultExpectedItems, defaultFalsePositiveRate),
+	}
+}
+
+// Run rotates the Bloom filters every window/2 until ctx is cancelled.
+func (f *Filter) Run(ctx context.Context) {
+	interval := f.window / 2
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.mu.Lock()
+			f.older = f.current
+			f.current = bloom.NewWithEstimates(defaultExpectedItems, defaultFalsePositiveRate)
+			f.mu.Unlock()
+		}
+	}
+}
+
+func key(sig, instructionType string, slot uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%s:%d", sig, instructionType, slot))
+}
+
+// Seen reports whether data is a duplicate of something already processed
+// within the window, anywhere in the fleet. Every key is confirmed against
+// Redis regardless of the local Bloom filters' result: a hit is confirmed
+// so a Bloom false positive never causes a false negative, and a miss is
+// still confirmed so a key only some other replica has seen is still
+// caught, which a Redis call gated behind a local hit would silently miss.
+func (f *Filter) Seen(ctx context.Context, data *models.EssentialData) (bool, error) {
+	k := key(data.TransactionSignature, data.InstructionType, data.Slot)
+
+	f.mu.RLock()
+	hit := f.current.Test(k) || f.older.Test(k)
+	f.mu.RUnlock()
+
+	if hit {
+		f.filterHits.Add(1)
+	}
+
+	dupe, err := f.confirm(ctx, k)
+	if err != nil {
+		return false, fmt.Errorf("dedup: failed to confirm via redis: %w", err)
+	}
+
+	f.mu.Lock()
+	f.current.Add(k)
+	f.mu.Unlock()
+
+	switch {
+	case dupe && hit:
+		f.confirmedDupes.Add(1)
+	case dupe && !hit:
+		f.crossReplicaDupes.Add(1)
+	case !dupe && hit:
+		f.falsePositiveConfirmations.Add(1)
+	}
+
+	return dupe, nil
+}
+
+// confirm claims the key in Redis via SET NX PX <window_ms>. It returns
+// true if the key already existed (a confirmed duplicate), and false if
+// this call claimed it (a first occurrence or a Bloom false positive) or
+// no Redis client is configured. A Redis error is returned as is.
+func (f *Filter) confirm(ctx context.Context, k []byte) (bool, error) {
+	if f.redis == nil {
+		return false, nil
+	}
+	ok, err := f.redis.SetNX(ctx, string(k), "", f.window).Result()
+	if err != nil {
+		return false, err
+	}
+	// SetNX returns true when it set the key (i.e. the key was absent).
+	return !ok, nil
+}
+
+// Stats returns a snapshot of the running counters.
+func (f *Filter) Stats() Stats {
+	return Stats{
+		FilterHits:                 f.filterHits.Load(),
+		ConfirmedDupes:             f.confirmedDupes.Load(),
+		FalsePositiveConfirmations: f.falsePositiveConfirmations.Load(),
+		CrossReplicaDupes:          f.crossReplicaDupes.Load(),
+	}
+}
@@ -0,0 +1,115 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestFilter(t *testing.T) *Filter {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return New(time.Minute, client)
+}
+
+func essentialData(sig string, slot uint64) *models.EssentialData {
+	return &models.EssentialData{
+		TransactionSignature: sig,
+		InstructionType:      "swap",
+		Slot:                 slot,
+	}
+}
+
+func TestSeenFirstOccurrenceIsNotADupe(t *testing.T) {
+	f := newTestFilter(t)
+
+	dupe, err := f.Seen(context.Background(), essentialData("sig-1", 1))
+	if err != nil {
+		t.Fatalf("Seen returned error: %v", err)
+	}
+	if dupe {
+		t.Fatal("first occurrence of a key should never be reported as a duplicate")
+	}
+}
+
+func TestSeenRepeatOnSameReplicaIsADupe(t *testing.T) {
+	f := newTestFilter(t)
+	ctx := context.Background()
+
+	if _, err := f.Seen(ctx, essentialData("sig-2", 1)); err != nil {
+		t.Fatalf("Seen returned error: %v", err)
+	}
+
+	dupe, err := f.Seen(ctx, essentialData("sig-2", 1))
+	if err != nil {
+		t.Fatalf("Seen returned error: %v", err)
+	}
+	if !dupe {
+		t.Fatal("a key seen twice by the same replica's Bloom filter should be reported as a duplicate")
+	}
+	if stats := f.Stats(); stats.ConfirmedDupes != 1 {
+		t.Fatalf("ConfirmedDupes = %d, want 1", stats.ConfirmedDupes)
+	}
+}
+
+// TestSeenCatchesCrossReplicaDupe is the regression test for the bug where
+// confirm (the Redis SET NX call) only ran on a local Bloom hit: a key
+// claimed in Redis by one Filter must still be caught as a duplicate by a
+// second Filter whose own Bloom filters have never seen it.
+func TestSeenCatchesCrossReplicaDupe(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client1 := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	client2 := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client1.Close(); client2.Close() })
+
+	replicaA := New(time.Minute, client1)
+	replicaB := New(time.Minute, client2)
+	ctx := context.Background()
+
+	data := essentialData("sig-3", 1)
+	if dupe, err := replicaA.Seen(ctx, data); err != nil || dupe {
+		t.Fatalf("replicaA.Seen = (%v, %v), want (false, nil)", dupe, err)
+	}
+
+	dupe, err := replicaB.Seen(ctx, data)
+	if err != nil {
+		t.Fatalf("replicaB.Seen returned error: %v", err)
+	}
+	if !dupe {
+		t.Fatal("a key claimed in Redis by another replica must be reported as a duplicate even on a local Bloom miss")
+	}
+	if stats := replicaB.Stats(); stats.CrossReplicaDupes != 1 {
+		t.Fatalf("replicaB CrossReplicaDupes = %d, want 1", stats.CrossReplicaDupes)
+	}
+}
+
+func TestSeenDistinctKeysAreNotDupes(t *testing.T) {
+	f := newTestFilter(t)
+	ctx := context.Background()
+
+	if _, err := f.Seen(ctx, essentialData("sig-4", 1)); err != nil {
+		t.Fatalf("Seen returned error: %v", err)
+	}
+	dupe, err := f.Seen(ctx, essentialData("sig-4", 2))
+	if err != nil {
+		t.Fatalf("Seen returned error: %v", err)
+	}
+	if dupe {
+		t.Fatal("a different slot for the same signature is a distinct key, not a duplicate")
+	}
+}
+
+func TestSeenFailsOpenWithoutRedis(t *testing.T) {
+	f := New(time.Minute, nil)
+	ctx := context.Background()
+
+	if dupe, err := f.Seen(ctx, essentialData("sig-5", 1)); err != nil || dupe {
+		t.Fatalf("Seen = (%v, %v), want (false, nil) with no redis client configured", dupe, err)
+	}
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newCustomCollector instantiates a single operator-declared metric from
+// MetricCollectorConfig, dispatching on its Type.
+func newCustomCollector(namespace string, constLabels prometheus.Labels, cc config.MetricCollectorConfig) (prometheus.Collector, error) {
+	switch cc.Type {
+	case "counter":
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        cc.Name,
+			Help:        cc.Description,
+			ConstLabels: constLabels,
+		}, cc.Labels), nil
+
+	case "gauge":
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        cc.Name,
+			Help:        cc.Description,
+			ConstLabels: constLabels,
+		}, cc.Labels), nil
+
+	case "histogram":
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        cc.Name,
+			Help:        cc.Description,
+			ConstLabels: constLabels,
+			Buckets:     histogramBuckets(cc.Config),
+		}, cc.Labels), nil
+
+	case "summary":
+		return prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace:   namespace,
+			Name:        cc.Name,
+			Help:        cc.Description,
+			ConstLabels: constLabels,
+			Objectives:  summaryObjectives(cc.Config),
+		}, cc.Labels), nil
+
+	default:
+		return nil, fmt.Errorf("metrics: unknown custom collector type %q for %q", cc.Type, cc.Name)
+	}
+}
+
+func histogramBuckets(cfg map[string]interface{}) []float64 {
+	raw, ok := cfg["buckets"].([]interface{})
+	if !ok {
+		return prometheus.DefBuckets
+	}
+	buckets := make([]float64, 0, len(raw))
+	for _, v := range raw {
+		if f, ok := toFloat64(v); ok {
+			buckets = append(buckets, f)
+		}
+	}
+	if len(buckets) == 0 {
+		return prometheus.DefBuckets
+	}
+	return buckets
+}
+
+func summaryObjectives(cfg map[string]interface{}) map[float64]float64 {
+	raw, ok := cfg["quantiles"].(map[string]interface{})
+	if !ok {
+		return map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+	}
+	objectives := make(map[float64]float64, len(raw))
+	for quantile, errAllowed := range raw {
+		q, qOk := toFloat64(quantile)
+		e, eOk := toFloat64(errAllowed)
+		if qOk && eOk {
+			objectives[q] = e
+		}
+	}
+	return objectives
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		var f float64
+		if _, err := fmt.Sscanf(n, "%f", &f); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,29 @@
+package metrics
+
+// knownPrograms mirrors the program_id -> name mapping the Go client
+// exposes via models.SupportedPrograms(). It's duplicated here rather than
+// imported because that package lives under go-client/internal and, per
+// Go's internal-package visibility rule, can never be imported from
+// another module's import path, even inside a workspace. Keep this in sync
+// with go-client/internal/models.SupportedPrograms() when a program is
+// added or removed.
+var knownPrograms = map[string]string{
+	"TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb":  "SPL Token Program",
+	"6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P":  "Pump.fun",
+	"39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg": "Pump.fun AMM",
+	"675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8": "Raydium Liquidity Pool V4",
+	"LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo":  "Meteora DLMM",
+}
+
+const unknownProgramLabel = "unknown"
+
+// SafeProgramLabel caps program_name label cardinality to the programs
+// knownPrograms lists: programID is resolved to its readable name when
+// recognized, and collapses to "unknown" otherwise, so an unrecognized
+// program id in the wild can't explode the metric's series count.
+func SafeProgramLabel(programID string) string {
+	if name, ok := knownPrograms[programID]; ok {
+		return name
+	}
+	return unknownProgramLabel
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import "testing"
+
+// TestSafeProgramLabelKnownPrograms locks in the program_id -> name mapping
+// this file carries independently of go-client/internal/models, since
+// importing that package from here would cross Go's internal-package
+// visibility boundary and fail to build (see the earlier, now-fixed commit
+// history of this file).
+func TestSafeProgramLabelKnownPrograms(t *testing.T) {
+	cases := map[string]string{
+		"TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb":  "SPL Token Program",
+		"6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P":  "Pump.fun",
+		"39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg": "Pump.fun AMM",
+		"675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8": "Raydium Liquidity Pool V4",
+		"LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo":  "Meteora DLMM",
+	}
+	for programID, want := range cases {
+		if got := SafeProgramLabel(programID); got != want {
+			t.Errorf("SafeProgramLabel(%q) = %q, want %q", programID, got, want)
+		}
+	}
+}
+
+func TestSafeProgramLabelUnknownProgram(t *testing.T) {
+	if got := SafeProgramLabel("not-a-real-program-id"); got != unknownProgramLabel {
+		t.Errorf("SafeProgramLabel(unknown) = %q, want %q", got, unknownProgramLabel)
+	}
+}
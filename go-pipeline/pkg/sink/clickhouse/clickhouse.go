@@ -0,0 +1,212 @@
+// Package clickhouse implements a Sink that batches writes to ClickHouse
+// over its native protocol, buffering a separate columnar batch per
+// ProgramName so mixed-program writes don't thrash per-column compression.
+// It also implements sink.Reader, making it the natural replay source: its
+// slot-ordered range scans are exactly what replay.Runner needs.
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	"go.uber.org/zap"
+)
+
+var _ sink.Sink = (*Sink)(nil)
+var _ sink.Reader = (*Sink)(nil)
+
+// Sink batches writes to ClickHouse, keeping one open driver.Batch per
+// ProgramName per table until it reaches BatchSize or Flush is called.
+type Sink struct {
+	conn driver.Conn
+	cfg  config.ClickHouseConfig
+	log  *zap.Logger
+
+	mu                 sync.Mutex
+	accountBatches     map[string]driver.Batch
+	instructionBatches map[string]driver.Batch
+}
+
+// New opens a native-protocol connection to ClickHouse.
+func New(ctx context.Context, cfg config.ClickHouseConfig, log *zap.Logger) (*Sink, error) {
+	conn, err := ch.Open(&ch.Options{
+		Addr: cfg.Addrs,
+		Auth: ch.Auth{
+			Database: cfg.Database,
+			Username: cfg.Username,
+			Password: cfg.Password,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: failed to open connection: %w", err)
+	}
+	if err := conn.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("clickhouse: ping failed: %w", err)
+	}
+
+	return &Sink{
+		conn:               conn,
+		cfg:                cfg,
+		log:                log,
+		accountBatches:     make(map[string]driver.Batch),
+		instructionBatches: make(map[string]driver.Batch),
+	}, nil
+}
+
+// Name identifies this sink in logs and metrics.
+func (s *Sink) Name() string { return "clickhouse" }
+
+// WriteAccounts appends rows to a per-program accounts batch, sending it
+// once it reaches ClickHouseConfig.BatchSize.
+func (s *Sink) WriteAccounts(ctx context.Context, rows []sink.AccountRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range rows {
+		batch, err := s.accountBatch(ctx, r.ProgramName)
+		if err != nil {
+			return err
+		}
+		if err := batch.Append(r.ID, r.AccountPubkey, r.ProgramID, r.ProgramName, r.Slot, r.BlockTime, r.IngestedAt, r.RawData, r.Lamports, r.Owner, r.Mode); err != nil {
+			return fmt.Errorf("clickhouse: failed to append account row for %s: %w", r.ProgramName, err)
+		}
+		if batch.Rows() >= s.cfg.BatchSize {
+			if err := batch.Send(); err != nil {
+				return fmt.Errorf("clickhouse: failed to send accounts batch for %s: %w", r.ProgramName, err)
+			}
+			delete(s.accountBatches, r.ProgramName)
+		}
+	}
+	return nil
+}
+
+// WriteInstructions appends rows to a per-program instructions batch,
+// sending it once it reaches ClickHouseConfig.BatchSize.
+func (s *Sink) WriteInstructions(ctx context.Context, rows []sink.InstructionRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range rows {
+		batch, err := s.instructionBatch(ctx, r.ProgramName)
+		if err != nil {
+			return err
+		}
+		if err := batch.Append(r.ID, r.Signature, r.ProgramID, r.ProgramName, r.InstructionIndex, r.Slot, r.BlockTime, r.IngestedAt, r.RawData, r.InstructionType, r.IsTrading, r.Mode); err != nil {
+			return fmt.Errorf("clickhouse: failed to append instruction row for %s: %w", r.ProgramName, err)
+		}
+		if batch.Rows() >= s.cfg.BatchSize {
+			if err := batch.Send(); err != nil {
+				return fmt.Errorf("clickhouse: failed to send instructions batch for %s: %w", r.ProgramName, err)
+			}
+			delete(s.instructionBatches, r.ProgramName)
+		}
+	}
+	return nil
+}
+
+func (s *Sink) accountBatch(ctx context.Context, programName string) (driver.Batch, error) {
+	if batch, ok := s.accountBatches[programName]; ok {
+		return batch, nil
+	}
+	batch, err := s.conn.PrepareBatch(ctx, "INSERT INTO accounts")
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: failed to prepare accounts batch for %s: %w", programName, err)
+	}
+	s.accountBatches[programName] = batch
+	return batch, nil
+}
+
+func (s *Sink) instructionBatch(ctx context.Context, programName string) (driver.Batch, error) {
+	if batch, ok := s.instructionBatches[programName]; ok {
+		return batch, nil
+	}
+	batch, err := s.conn.PrepareBatch(ctx, "INSERT INTO transactions")
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: failed to prepare instructions batch for %s: %w", programName, err)
+	}
+	s.instructionBatches[programName] = batch
+	return batch, nil
+}
+
+// ReadInstructions streams every instruction row in [minSlot, maxSlot] back
+// out of the transactions table, oldest slot first, for replay.Runner.
+func (s *Sink) ReadInstructions(ctx context.Context, minSlot, maxSlot uint64) (<-chan sink.InstructionRow, <-chan error) {
+	rows := make(chan sink.InstructionRow)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(rows)
+		defer close(errs)
+
+		result, err := s.conn.Query(ctx,
+			`SELECT id, signature, program_id, program_name, instruction_index, slot, block_time, ingested_at, raw_data, instruction_type, is_trading
+			 FROM transactions WHERE slot >= ? AND slot <= ? ORDER BY slot ASC`,
+			minSlot, maxSlot,
+		)
+		if err != nil {
+			errs <- fmt.Errorf("clickhouse: failed to query transactions for replay: %w", err)
+			return
+		}
+		defer result.Close()
+
+		for result.Next() {
+			var r sink.InstructionRow
+			if err := result.Scan(&r.ID, &r.Signature, &r.ProgramID, &r.ProgramName, &r.InstructionIndex, &r.Slot, &r.BlockTime, &r.IngestedAt, &r.RawData, &r.InstructionType, &r.IsTrading); err != nil {
+				errs <- fmt.Errorf("clickhouse: failed to scan replay row: %w", err)
+				return
+			}
+			r.Mode = sink.ModeReplay
+			select {
+			case rows <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := result.Err(); err != nil {
+			errs <- fmt.Errorf("clickhouse: replay query failed: %w", err)
+		}
+	}()
+
+	return rows, errs
+}
+
+// Flush sends every batch still buffered, regardless of size.
+func (s *Sink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for programName, batch := range s.accountBatches {
+		if err := batch.Send(); err != nil {
+			return fmt.Errorf("clickhouse: failed to flush accounts batch for %s: %w", programName, err)
+		}
+		delete(s.accountBatches, programName)
+	}
+	for programName, batch := range s.instructionBatches {
+		if err := batch.Send(); err != nil {
+			return fmt.Errorf("clickhouse: failed to flush instructions batch for %s: %w", programName, err)
+		}
+		delete(s.instructionBatches, programName)
+	}
+	return nil
+}
+
+// Close flushes any remaining batches and closes the connection.
+func (s *Sink) Close() error {
+	if err := s.Flush(context.Background()); err != nil {
+		s.log.Error("clickhouse: failed to flush on close", zap.Error(err))
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,127 @@
+// Package kafka implements a Sink that publishes ingested rows to Kafka,
+// one topic per program so downstream consumers can subscribe to a subset
+// of programs, keyed by signature or account_pubkey so all events for the
+// same transaction or account land on the same partition.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+var _ sink.Sink = (*Sink)(nil)
+
+// Sink publishes rows to Kafka via kafka-go, routing each message to its
+// program's topic and partitioning by key.
+type Sink struct {
+	writer *kafkago.Writer
+	cfg    config.KafkaConfig
+	log    *zap.Logger
+}
+
+// New builds a Sink writing to cfg.Brokers. Unlike a Writer bound to a
+// single topic, Balancer-based hashing is applied per message so each
+// program can be routed to its own topic.
+func New(cfg config.KafkaConfig, log *zap.Logger) *Sink {
+	writer := &kafkago.Writer{
+		Addr:            kafkago.TCP(cfg.Brokers...),
+		Balancer:        &kafkago.Hash{},
+		RequiredAcks:    requiredAcks(cfg.RequiredAcks),
+		MaxAttempts:     cfg.MaxRetries,
+		WriteBackoffMin: cfg.RetryBackoff,
+	}
+	return &Sink{writer: writer, cfg: cfg, log: log}
+}
+
+func requiredAcks(acks string) kafkago.RequiredAcks {
+	switch acks {
+	case "none":
+		return kafkago.RequireNone
+	case "all":
+		return kafkago.RequireAll
+	default:
+		return kafkago.RequireOne
+	}
+}
+
+// Name identifies this sink in logs and metrics.
+func (s *Sink) Name() string { return "kafka" }
+
+// topic returns the topic a program's rows are published to:
+// KafkaConfig.TopicPrefix followed by the program name, lowercased with
+// spaces replaced by underscores.
+func (s *Sink) topic(programName string) string {
+	slug := strings.ToLower(strings.ReplaceAll(programName, " ", "_"))
+	return s.cfg.TopicPrefix + slug
+}
+
+// WriteAccounts publishes each row, keyed by account pubkey, to its
+// program's topic.
+func (s *Sink) WriteAccounts(ctx context.Context, rows []sink.AccountRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	messages := make([]kafkago.Message, len(rows))
+	for i, r := range rows {
+		value, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("kafka: failed to marshal account row: %w", err)
+		}
+		messages[i] = kafkago.Message{
+			Topic: s.topic(r.ProgramName),
+			Key:   []byte(r.AccountPubkey),
+			Value: value,
+		}
+	}
+
+	if err := s.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("kafka: failed to write account messages: %w", err)
+	}
+	return nil
+}
+
+// WriteInstructions publishes each row, keyed by transaction signature, to
+// its program's topic.
+func (s *Sink) WriteInstructions(ctx context.Context, rows []sink.InstructionRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	messages := make([]kafkago.Message, len(rows))
+	for i, r := range rows {
+		value, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("kafka: failed to marshal instruction row: %w", err)
+		}
+		messages[i] = kafkago.Message{
+			Topic: s.topic(r.ProgramName),
+			Key:   []byte(r.Signature),
+			Value: value,
+		}
+	}
+
+	if err := s.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("kafka: failed to write instruction messages: %w", err)
+	}
+	return nil
+}
+
+// Flush is a no-op: WriteMessages already blocks until the broker
+// acknowledges each batch per RequiredAcks. It exists to satisfy sink.Sink.
+func (s *Sink) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Close flushes any writes still in flight and closes the underlying
+// connections.
+func (s *Sink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,156 @@
+// Package parquet implements a Sink that buffers rows into Parquet files
+// rotated by slot range, uploading each rotated file to the object store
+// behind the configured Uploader (S3Uploader is the only implementation)
+// once its slot range (highest minus lowest buffered slot) reaches
+// ParquetConfig.SlotsPerFile or Flush is called.
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/sink"
+	pq "github.com/parquet-go/parquet-go"
+	"go.uber.org/zap"
+)
+
+var _ sink.Sink = (*Sink)(nil)
+
+// Uploader abstracts the object store a rotated Parquet file is written
+// to, so tests can swap in a fake without talking to S3 or GCS.
+type Uploader interface {
+	Upload(ctx context.Context, key string, data []byte) error
+}
+
+// Sink buffers accounts and instructions in memory and writes them out as
+// Parquet files once the buffered slot range reaches SlotsPerFile.
+type Sink struct {
+	cfg      config.ParquetConfig
+	uploader Uploader
+	log      *zap.Logger
+
+	mu              sync.Mutex
+	accountRows     []sink.AccountRow
+	instructionRows []sink.InstructionRow
+	minSlot         uint64
+	maxSlot         uint64
+}
+
+// New builds a Sink that uploads rotated files via uploader.
+func New(cfg config.ParquetConfig, uploader Uploader, log *zap.Logger) *Sink {
+	return &Sink{cfg: cfg, uploader: uploader, log: log}
+}
+
+// Name identifies this sink in logs and metrics.
+func (s *Sink) Name() string { return "parquet" }
+
+// WriteAccounts buffers rows, rotating to a new file once the buffered
+// slot range reaches SlotsPerFile.
+func (s *Sink) WriteAccounts(ctx context.Context, rows []sink.AccountRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accountRows = append(s.accountRows, rows...)
+	for _, r := range rows {
+		s.trackSlotLocked(r.Slot)
+	}
+	return s.rotateIfDueLocked(ctx)
+}
+
+// WriteInstructions buffers rows, rotating to a new file once the
+// buffered slot range reaches SlotsPerFile.
+func (s *Sink) WriteInstructions(ctx context.Context, rows []sink.InstructionRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.instructionRows = append(s.instructionRows, rows...)
+	for _, r := range rows {
+		s.trackSlotLocked(r.Slot)
+	}
+	return s.rotateIfDueLocked(ctx)
+}
+
+func (s *Sink) trackSlotLocked(slot uint64) {
+	if s.minSlot == 0 || slot < s.minSlot {
+		s.minSlot = slot
+	}
+	if slot > s.maxSlot {
+		s.maxSlot = slot
+	}
+}
+
+func (s *Sink) rotateIfDueLocked(ctx context.Context) error {
+	if s.maxSlot-s.minSlot < s.cfg.SlotsPerFile {
+		return nil
+	}
+	return s.flushLocked(ctx)
+}
+
+// Flush forces out whatever is buffered, regardless of slot range.
+func (s *Sink) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flushLocked(ctx)
+}
+
+func (s *Sink) flushLocked(ctx context.Context) error {
+	if len(s.accountRows) == 0 && len(s.instructionRows) == 0 {
+		return nil
+	}
+
+	if len(s.accountRows) > 0 {
+		if err := s.uploadAccounts(ctx); err != nil {
+			return err
+		}
+		s.accountRows = nil
+	}
+	if len(s.instructionRows) > 0 {
+		if err := s.uploadInstructions(ctx); err != nil {
+			return err
+		}
+		s.instructionRows = nil
+	}
+
+	s.minSlot, s.maxSlot = 0, 0
+	return nil
+}
+
+func (s *Sink) uploadAccounts(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := pq.Write(&buf, s.accountRows); err != nil {
+		return fmt.Errorf("parquet: failed to encode accounts: %w", err)
+	}
+	return s.upload(ctx, "accounts", buf.Bytes())
+}
+
+func (s *Sink) uploadInstructions(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := pq.Write(&buf, s.instructionRows); err != nil {
+		return fmt.Errorf("parquet: failed to encode instructions: %w", err)
+	}
+	return s.upload(ctx, "instructions", buf.Bytes())
+}
+
+func (s *Sink) upload(ctx context.Context, table string, data []byte) error {
+	key := fmt.Sprintf("%s%s/slot-%d-%d.parquet", s.cfg.Upload.Prefix, table, s.minSlot, s.maxSlot)
+	if err := s.uploader.Upload(ctx, key, data); err != nil {
+		return fmt.Errorf("parquet: failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// Close flushes any buffered rows.
+func (s *Sink) Close() error {
+	return s.Flush(context.Background())
+}
@@ -0,0 +1,42 @@
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+var _ Uploader = (*S3Uploader)(nil)
+
+// S3Uploader uploads rotated Parquet files to an S3 bucket.
+type S3Uploader struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Uploader builds an S3Uploader for the given bucket and region using
+// the default AWS credential chain.
+func NewS3Uploader(ctx context.Context, region, bucket string) (*S3Uploader, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("parquet: failed to load AWS config: %w", err)
+	}
+	return &S3Uploader{client: s3.NewFromConfig(cfg), bucket: bucket}, nil
+}
+
+// Upload puts data at key in the uploader's bucket.
+func (u *S3Uploader) Upload(ctx context.Context, key string, data []byte) error {
+	_, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("parquet: failed to put %s/%s: %w", u.bucket, key, err)
+	}
+	return nil
+}
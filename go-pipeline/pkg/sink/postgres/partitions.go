@@ -0,0 +1,335 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// maintenanceLookahead is how many future partitions are pre-created on
+// every maintenance run.
+const maintenanceLookahead = 3
+
+// tableColumnDDL holds the CREATE TABLE column list for each parent table,
+// column for column and in the same order WriteAccounts, WriteInstructions,
+// WriteBlocks, and WriteTradeEvents COPY into (and ReadInstructions selects
+// back out of) in postgres.go - so the schema this package creates always
+// matches the columns it actually reads and writes.
+var tableColumnDDL = map[string]string{
+	"accounts": `
+		id BIGSERIAL,
+		account_pubkey TEXT NOT NULL,
+		program_id TEXT NOT NULL,
+		program_name TEXT,
+		slot BIGINT NOT NULL,
+		block_time TIMESTAMPTZ,
+		ingested_at TIMESTAMPTZ NOT NULL,
+		raw_data BYTEA,
+		lamports BIGINT NOT NULL DEFAULT 0,
+		owner TEXT,
+		mode TEXT NOT NULL DEFAULT 'live'`,
+	"transactions": `
+		id BIGSERIAL,
+		signature TEXT NOT NULL,
+		program_id TEXT NOT NULL,
+		program_name TEXT,
+		instruction_index INT NOT NULL DEFAULT 0,
+		slot BIGINT NOT NULL,
+		block_time TIMESTAMPTZ,
+		ingested_at TIMESTAMPTZ NOT NULL,
+		raw_data BYTEA,
+		instruction_type TEXT,
+		is_trading BOOLEAN NOT NULL DEFAULT false,
+		mode TEXT NOT NULL DEFAULT 'live'`,
+	"blocks": `
+		slot BIGINT NOT NULL,
+		block_time TIMESTAMPTZ NOT NULL,
+		block_hash TEXT`,
+	"trade_events": `
+		signature TEXT,
+		program_name TEXT,
+		slot BIGINT NOT NULL,
+		block_time TIMESTAMPTZ NOT NULL,
+		raw_data BYTEA,
+		mode TEXT NOT NULL DEFAULT 'live'`,
+}
+
+// defaultPartitionColumn returns the partition column a TableConfig uses
+// when it doesn't set PartitionColumn explicitly: ingested_at for the
+// tables that have one, block_time for the two that don't.
+func defaultPartitionColumn(kind string) string {
+	switch kind {
+	case "blocks", "trade_events":
+		return "block_time"
+	default:
+		return "ingested_at"
+	}
+}
+
+// createPartitionedTables creates the parent tables (PARTITION BY RANGE on
+// each TableConfig.PartitionColumn) and their declared indexes if they do
+// not already exist, then pre-creates the first batch of partitions.
+func (s *Sink) createPartitionedTables(ctx context.Context) error {
+	for _, table := range []struct {
+		kind string
+		tc   config.TableConfig
+	}{
+		{"accounts", s.cfg.Tables.Accounts},
+		{"transactions", s.cfg.Tables.Transactions},
+		{"blocks", s.cfg.Tables.Blocks},
+		{"trade_events", s.cfg.Tables.TradeEvents},
+	} {
+		tc := table.tc
+		if tc.Name == "" {
+			continue
+		}
+		if err := s.createParentTable(ctx, table.kind, tc); err != nil {
+			return err
+		}
+		for _, col := range tc.IndexColumns {
+			if err := s.createIndex(ctx, tc, col); err != nil {
+				return err
+			}
+		}
+		if s.cfg.Partitioning.Enabled {
+			if err := s.ensurePartitions(ctx, tc, time.Now(), maintenanceLookahead); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sink) createParentTable(ctx context.Context, kind string, tc config.TableConfig) error {
+	columns, ok := tableColumnDDL[kind]
+	if !ok {
+		return fmt.Errorf("postgres: no column schema registered for table kind %q", kind)
+	}
+
+	partitionColumn := tc.PartitionColumn
+	if partitionColumn == "" {
+		partitionColumn = defaultPartitionColumn(kind)
+	}
+
+	ddl := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (%s
+	) PARTITION BY RANGE (%s)`, tc.Name, columns, partitionColumn)
+
+	if _, err := s.pool.Exec(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create parent table %s: %w", tc.Name, err)
+	}
+	return nil
+}
+
+func (s *Sink) createIndex(ctx context.Context, tc config.TableConfig, column string) error {
+	indexName := fmt.Sprintf("idx_%s_%s", tc.Name, column)
+	ddl := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", indexName, tc.Name, column)
+	if _, err := s.pool.Exec(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", indexName, err)
+	}
+	return nil
+}
+
+// ensurePartitions creates up to lookahead future partitions of tc starting
+// from `from`, using the configured partitioning strategy.
+func (s *Sink) ensurePartitions(ctx context.Context, tc config.TableConfig, from time.Time, lookahead int) error {
+	start := rangeStart(s.cfg.Partitioning.Strategy, from)
+	for i := 0; i < lookahead; i++ {
+		end := rangeEnd(s.cfg.Partitioning.Strategy, start)
+		if err := s.createPartition(ctx, tc, start, end); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func (s *Sink) createPartition(ctx context.Context, tc config.TableConfig, start, end time.Time) error {
+	partitionName := fmt.Sprintf("%s_%s", tc.Name, start.Format("20060102"))
+	ddl := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+		partitionName, tc.Name, start.Format(time.RFC3339), end.Format(time.RFC3339),
+	)
+	if _, err := s.pool.Exec(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", partitionName, err)
+	}
+	return nil
+}
+
+// listPartitions returns the names of every partition currently attached to
+// tableName, as reported by pg_inherits.
+func (s *Sink) listPartitions(ctx context.Context, tableName string) ([]string, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON pg_inherits.inhparent = parent.oid
+		JOIN pg_class child ON pg_inherits.inhrelid = child.oid
+		WHERE parent.relname = $1`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition name: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// dropExpiredPartitions drops partitions of tc whose upper bound is older
+// than tc.RetentionDays (falling back to the global partitioning retention
+// when the table does not override it).
+func (s *Sink) dropExpiredPartitions(ctx context.Context, tc config.TableConfig) error {
+	retentionDays := tc.RetentionDays
+	if retentionDays <= 0 {
+		retentionDays = s.cfg.Partitioning.RetentionDays
+	}
+	if retentionDays <= 0 {
+		return nil
+	}
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+
+	names, err := s.listPartitions(ctx, tc.Name)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, name := range names {
+		if t, ok := partitionDate(tc.Name, name); ok && rangeEnd(s.cfg.Partitioning.Strategy, t).Before(cutoff) {
+			stale = append(stale, name)
+		}
+	}
+
+	for _, name := range stale {
+		if _, err := s.pool.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", name)); err != nil {
+			return fmt.Errorf("failed to drop expired partition %s: %w", name, err)
+		}
+		s.log.Info("dropped expired partition", zap.String("table", tc.Name), zap.String("partition", name))
+	}
+	return nil
+}
+
+// maybeCompress applies TimescaleDB compression to a partition when the
+// table declares a CompressionType.
+func (s *Sink) maybeCompress(ctx context.Context, tc config.TableConfig, partitionName string) {
+	if tc.CompressionType == "" {
+		return
+	}
+	if _, err := s.pool.Exec(ctx, "SELECT compress_chunk($1)", partitionName); err != nil {
+		s.log.Warn("failed to compress partition",
+			zap.String("partition", partitionName), zap.String("compression_type", tc.CompressionType), zap.Error(err))
+	}
+}
+
+// compressSealedPartitions compresses every partition of tc that has fully
+// closed (i.e. every partition whose range starts before the current one;
+// the current partition and any pre-created future ones are skipped),
+// since compress_chunk only makes sense once a chunk is no longer being
+// actively written to.
+func (s *Sink) compressSealedPartitions(ctx context.Context, tc config.TableConfig) error {
+	if tc.CompressionType == "" {
+		return nil
+	}
+	currentStart := rangeStart(s.cfg.Partitioning.Strategy, time.Now())
+
+	names, err := s.listPartitions(ctx, tc.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if t, ok := partitionDate(tc.Name, name); ok && t.Before(currentStart) {
+			s.maybeCompress(ctx, tc, name)
+		}
+	}
+	return nil
+}
+
+// startMaintenance schedules runMaintenance on MaintenanceSchedule.
+func (s *Sink) startMaintenance(ctx context.Context) error {
+	schedule := s.cfg.Partitioning.MaintenanceSchedule
+	if schedule == "" {
+		schedule = "@daily"
+	}
+
+	maintCtx, cancel := context.WithCancel(ctx)
+	s.cronCancel = cancel
+
+	c := cron.New()
+	if _, err := c.AddFunc(schedule, func() { s.runMaintenance(maintCtx) }); err != nil {
+		cancel()
+		return fmt.Errorf("invalid maintenance_schedule %q: %w", schedule, err)
+	}
+	s.cron = c
+	c.Start()
+	return nil
+}
+
+func (s *Sink) runMaintenance(ctx context.Context) {
+	for _, tc := range []config.TableConfig{
+		s.cfg.Tables.Accounts,
+		s.cfg.Tables.Transactions,
+		s.cfg.Tables.Blocks,
+		s.cfg.Tables.TradeEvents,
+	} {
+		if tc.Name == "" {
+			continue
+		}
+		if err := s.ensurePartitions(ctx, tc, time.Now(), maintenanceLookahead); err != nil {
+			s.log.Error("failed to pre-create partitions", zap.String("table", tc.Name), zap.Error(err))
+		}
+		if err := s.dropExpiredPartitions(ctx, tc); err != nil {
+			s.log.Error("failed to drop expired partitions", zap.String("table", tc.Name), zap.Error(err))
+		}
+		if err := s.compressSealedPartitions(ctx, tc); err != nil {
+			s.log.Error("failed to compress sealed partitions", zap.String("table", tc.Name), zap.Error(err))
+		}
+	}
+}
+
+func rangeStart(strategy string, t time.Time) time.Time {
+	t = t.UTC()
+	switch strategy {
+	case "weekly":
+		offset := int(t.Weekday())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -offset)
+	case "monthly":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default: // daily
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func rangeEnd(strategy string, start time.Time) time.Time {
+	switch strategy {
+	case "weekly":
+		return start.AddDate(0, 0, 7)
+	case "monthly":
+		return start.AddDate(0, 1, 0)
+	default: // daily
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// partitionDate extracts the YYYYMMDD suffix from a partition name of the
+// form "<table>_<suffix>" and parses it back to a time for retention checks.
+func partitionDate(table, partition string) (time.Time, bool) {
+	prefix := table + "_"
+	if len(partition) <= len(prefix) || partition[:len(prefix)] != prefix {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("20060102", partition[len(prefix):])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
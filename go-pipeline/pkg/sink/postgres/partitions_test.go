@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeStartDaily(t *testing.T) {
+	got := rangeStart("daily", time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC))
+	want := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("rangeStart(daily) = %v, want %v", got, want)
+	}
+}
+
+func TestRangeStartWeekly(t *testing.T) {
+	// 2026-03-05 is a Thursday (weekday 4); the week should start on Sunday.
+	got := rangeStart("weekly", time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC))
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("rangeStart(weekly) = %v, want %v", got, want)
+	}
+}
+
+func TestRangeStartMonthly(t *testing.T) {
+	got := rangeStart("monthly", time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC))
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("rangeStart(monthly) = %v, want %v", got, want)
+	}
+}
+
+func TestRangeEnd(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		strategy string
+		want     time.Time
+	}{
+		{"daily", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"weekly", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got := rangeEnd(c.strategy, start); !got.Equal(c.want) {
+			t.Errorf("rangeEnd(%q) = %v, want %v", c.strategy, got, c.want)
+		}
+	}
+}
+
+func TestPartitionDate(t *testing.T) {
+	got, ok := partitionDate("solana_accounts", "solana_accounts_20260305")
+	if !ok {
+		t.Fatal("partitionDate returned ok=false for a well-formed partition name")
+	}
+	want := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("partitionDate = %v, want %v", got, want)
+	}
+}
+
+func TestPartitionDateRejectsUnrelatedTable(t *testing.T) {
+	if _, ok := partitionDate("solana_accounts", "solana_transactions_20260305"); ok {
+		t.Fatal("partitionDate should reject a partition name from a different table")
+	}
+}
+
+func TestPartitionDateRejectsMalformedSuffix(t *testing.T) {
+	if _, ok := partitionDate("solana_accounts", "solana_accounts_notadate"); ok {
+		t.Fatal("partitionDate should reject a non-date suffix")
+	}
+}
@@ -0,0 +1,106 @@
+// Package sink defines the row types and the Sink interface every storage
+// backend the batch processor writes to implements: PostgreSQL,
+// ClickHouse, Kafka, and Parquet-on-S3 (there is no GCS uploader).
+// SinksConfig.Enabled selects which backends run; the batch processor
+// writes to all of them in parallel, each retrying independently, so
+// analytics consumers can tap the stream without hammering the primary
+// database. Each flush waits for every sink, so a slow sink delays the
+// next flush to the others.
+package sink
+
+import (
+	"context"
+	"time"
+)
+
+// Live and Replay distinguish rows the pipeline is writing as they arrive
+// from rows a replay.Runner is re-feeding from a historical slot range, so
+// operators can diff-check a backfill against the original rows before
+// trusting it (e.g. after a decoder bug fix) rather than it silently
+// overwriting or commingling with live data.
+const (
+	ModeLive   = "live"
+	ModeReplay = "replay"
+)
+
+// AccountRow is a single account update destined for a sink.
+type AccountRow struct {
+	ID            string
+	AccountPubkey string
+	ProgramID     string
+	ProgramName   string
+	Slot          uint64
+	BlockTime     *time.Time
+	IngestedAt    time.Time
+	RawData       []byte
+	Lamports      uint64
+	Owner         string
+	// Mode is ModeLive or ModeReplay; see the Mode constants.
+	Mode string
+}
+
+// InstructionRow is a single instruction destined for a sink.
+type InstructionRow struct {
+	ID               string
+	Signature        string
+	ProgramID        string
+	ProgramName      string
+	InstructionIndex uint32
+	Slot             uint64
+	BlockTime        *time.Time
+	IngestedAt       time.Time
+	RawData          []byte
+	InstructionType  string
+	IsTrading        bool
+	// Mode is ModeLive or ModeReplay; see the Mode constants.
+	Mode string
+}
+
+// BlockRow is a single block destined for a sink.
+type BlockRow struct {
+	Slot      uint64
+	BlockTime time.Time
+	BlockHash string
+}
+
+// TradeEventRow is a single trade event destined for a sink.
+type TradeEventRow struct {
+	Signature   string
+	ProgramName string
+	Slot        uint64
+	BlockTime   *time.Time
+	RawData     []byte
+	// Mode is ModeLive or ModeReplay; see the Mode constants.
+	Mode string
+}
+
+// Reader is implemented by sinks that can serve their own historical rows
+// back out, so replay.Runner can read a slot range from whichever
+// configured sink is queryable rather than requiring a dedicated replay
+// archive. Not every Sink implements it (Kafka and Parquet are
+// write-only from the pipeline's perspective); the replay command's
+// buildReplaySinks type-asserts for it and reports an error if no
+// selected sink does.
+type Reader interface {
+	// ReadInstructions streams every instruction row with minSlot <= slot
+	// <= maxSlot, oldest first. The rows channel is closed when the read
+	// completes or ctx is cancelled; a send on the errs channel means the
+	// read failed partway through and rows will receive no further sends.
+	ReadInstructions(ctx context.Context, minSlot, maxSlot uint64) (rows <-chan InstructionRow, errs <-chan error)
+}
+
+// Sink is implemented by every backend the batch processor can write
+// ingested rows to.
+type Sink interface {
+	// Name identifies the sink in logs and metrics, e.g. "postgres".
+	Name() string
+
+	WriteAccounts(ctx context.Context, rows []AccountRow) error
+	WriteInstructions(ctx context.Context, rows []InstructionRow) error
+
+	// Flush forces out any rows the sink has buffered internally, e.g.
+	// ahead of a partition rotation or a Parquet file close.
+	Flush(ctx context.Context) error
+
+	Close() error
+}
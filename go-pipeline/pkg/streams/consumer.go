@@ -0,0 +1,321 @@
+// Package streams implements a Redis Streams consumer that ingests
+// EssentialData records as an alternative to the SSE client, for
+// deployments that front the pipeline with Redis instead of talking
+// directly to the Rust stream processor.
+package streams
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/config"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/internal/models"
+	"github.com/hs2973/yellowstone-vixen/go-pipeline/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Dispatch hands a decoded item to the worker pool. ack is called once the
+// item has been processed so the consumer knows whether to XACK it.
+type Dispatch func(ctx context.Context, data *models.EssentialData, ack func(error)) error
+
+// Consumer reads from one or more Redis Streams via a consumer group and
+// dispatches each message to the worker pool.
+type Consumer struct {
+	client redis.UniversalClient
+	cfg    config.RedisConfig
+	group  string
+	name   string
+
+	dispatch Dispatch
+	log      *zap.Logger
+	metrics  *metrics.Server
+
+	wg sync.WaitGroup
+}
+
+// NewConsumer builds a Consumer from the Redis and pipeline configuration.
+// dispatch is typically (*pipeline.WorkerPool).Submit. metricsServer may be
+// nil, in which case events simply aren't counted.
+func NewConsumer(cfg config.RedisConfig, pipelineCfg config.PipelineConfig, dispatch Dispatch, metricsServer *metrics.Server, log *zap.Logger) (*Consumer, error) {
+	if len(cfg.StreamNames) == 0 {
+		return nil, fmt.Errorf("streams: redis.stream_names cannot be empty")
+	}
+
+	client, err := NewRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Consumer{
+		client:   client,
+		cfg:      cfg,
+		group:    pipelineCfg.ConsumerGroupName,
+		name:     pipelineCfg.ConsumerName,
+		dispatch: dispatch,
+		metrics:  metricsServer,
+		log:      log,
+	}, nil
+}
+
+// NewRedisClient builds the redis.UniversalClient NewConsumer uses, from
+// RedisConfig alone, so other stages that also need Redis (the dedup
+// Filter's cross-replica confirm step) share identical connection settings
+// instead of duplicating this address/TLS wiring.
+func NewRedisClient(cfg config.RedisConfig) (redis.UniversalClient, error) {
+	opts := &redis.UniversalOptions{
+		Addrs:           addrs(cfg),
+		Username:        cfg.Authentication.Username,
+		Password:        cfg.Authentication.Password,
+		PoolSize:        cfg.ConnectionPool.MaxActive,
+		MinIdleConns:    cfg.ConnectionPool.MaxIdle,
+		ConnMaxIdleTime: cfg.ConnectionPool.IdleTimeout,
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := buildTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("streams: failed to build TLS config: %w", err)
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	// NewUniversalClient only returns a *ClusterClient when more than one
+	// address is configured, so ClusterMode builds one directly and stays a
+	// cluster client even with a single seed address; otherwise a single
+	// address yields a plain *Client.
+	if cfg.ClusterMode {
+		return redis.NewClusterClient(opts.Cluster()), nil
+	}
+	return redis.NewUniversalClient(opts), nil
+}
+
+func addrs(cfg config.RedisConfig) []string {
+	if cfg.ClusterMode && len(cfg.ClusterNodes) > 0 {
+		return cfg.ClusterNodes
+	}
+	return []string{strings.TrimPrefix(strings.TrimPrefix(cfg.URL, "redis://"), "rediss://")}
+}
+
+func buildTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Run ensures consumer groups exist and starts two goroutines per stream:
+// one reading new entries and one reclaiming stale pending ones.
+// It blocks until ctx is cancelled.
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.ensureGroups(ctx); err != nil {
+		return fmt.Errorf("streams: failed to ensure consumer groups: %w", err)
+	}
+
+	for _, stream := range c.cfg.StreamNames {
+		stream := stream
+		c.wg.Add(2)
+		go func() {
+			defer c.wg.Done()
+			c.readLoop(ctx, stream)
+		}()
+		go func() {
+			defer c.wg.Done()
+			c.claimLoop(ctx, stream)
+		}()
+	}
+
+	<-ctx.Done()
+	c.wg.Wait()
+	return ctx.Err()
+}
+
+// Close releases the underlying Redis client.
+func (c *Consumer) Close() error {
+	return c.client.Close()
+}
+
+func (c *Consumer) ensureGroups(ctx context.Context) error {
+	for _, stream := range c.cfg.StreamNames {
+		err := c.client.XGroupCreateMkStream(ctx, stream, c.group, "$").Err()
+		if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group for stream %s: %w", stream, err)
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) readLoop(ctx context.Context, stream string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		res, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.name,
+			Streams:  []string{stream, ">"},
+			Count:    int64(c.cfg.ReadCount),
+			Block:    c.cfg.BlockTimeout,
+		}).Result()
+
+		if err != nil {
+			if errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) {
+				continue
+			}
+			c.log.Error("redis stream read failed", zap.String("stream", stream), zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, streamRes := range res {
+			for _, msg := range streamRes.Messages {
+				c.handleMessage(ctx, stream, msg)
+			}
+		}
+	}
+}
+
+func (c *Consumer) handleMessage(ctx context.Context, stream string, msg redis.XMessage) {
+	if c.metrics != nil {
+		c.metrics.EventsReceived.WithLabelValues(stream).Inc()
+	}
+
+	data, err := decode(msg)
+	if err != nil {
+		c.log.Error("failed to decode stream message",
+			zap.String("stream", stream), zap.String("message_id", msg.ID), zap.Error(err))
+		// Malformed messages can never succeed; ack them so they don't
+		// clog the pending list.
+		c.client.XAck(ctx, stream, c.group, msg.ID)
+		return
+	}
+
+	err = c.dispatch(ctx, data, func(procErr error) {
+		if procErr != nil {
+			// Leave unacked; claimLoop will redeliver it after the idle
+			// threshold so crashed/failed processing doesn't lose work.
+			return
+		}
+		if ackErr := c.client.XAck(ctx, stream, c.group, msg.ID).Err(); ackErr != nil {
+			c.log.Error("failed to ack stream message",
+				zap.String("stream", stream), zap.String("message_id", msg.ID), zap.Error(ackErr))
+		}
+	})
+	if err != nil {
+		c.log.Error("failed to dispatch stream message to worker pool",
+			zap.String("stream", stream), zap.String("message_id", msg.ID), zap.Error(err))
+	}
+}
+
+func decode(msg redis.XMessage) (*models.EssentialData, error) {
+	raw, ok := msg.Values["data"]
+	if !ok {
+		return nil, fmt.Errorf("message %s has no \"data\" field", msg.ID)
+	}
+
+	payload, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("message %s \"data\" field is not a string", msg.ID)
+	}
+
+	var data models.EssentialData
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal essential data: %w", err)
+	}
+	return &data, nil
+}
+
+// claimLoop periodically scans the pending entries list for messages that
+// have been idle longer than ClaimIdleTimeout and claims them for this
+// consumer, so a crashed replica's in-flight work gets redelivered.
+func (c *Consumer) claimLoop(ctx context.Context, stream string) {
+	idleThreshold := c.cfg.ClaimIdleTimeout
+	if idleThreshold <= 0 {
+		idleThreshold = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(idleThreshold)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.claimPending(ctx, stream, idleThreshold)
+		}
+	}
+}
+
+func (c *Consumer) claimPending(ctx context.Context, stream string, idleThreshold time.Duration) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  c.group,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(c.cfg.ReadCount),
+	}).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.log.Error("failed to scan pending entries", zap.String("stream", stream), zap.Error(err))
+		}
+		return
+	}
+
+	var staleIDs []string
+	for _, p := range pending {
+		if p.Idle >= idleThreshold {
+			staleIDs = append(staleIDs, p.ID)
+		}
+	}
+	if len(staleIDs) == 0 {
+		return
+	}
+
+	msgs, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    c.group,
+		Consumer: c.name,
+		MinIdle:  idleThreshold,
+		Messages: staleIDs,
+	}).Result()
+	if err != nil {
+		c.log.Error("failed to claim stale entries", zap.String("stream", stream), zap.Error(err))
+		return
+	}
+
+	c.log.Warn("reclaimed stale pending messages",
+		zap.String("stream", stream), zap.Int("count", len(msgs)))
+
+	for _, msg := range msgs {
+		c.handleMessage(ctx, stream, msg)
+	}
+}
@@ -6,44 +6,146 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	instructionsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sse_client_instructions_processed_total",
+		Help: "Instructions processed by the SSE client, by program id.",
+	}, []string{"program_id"})
+
+	reconnectsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sse_client_reconnects_total",
+		Help: "Number of times the SSE client has reconnected to the stream.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(instructionsProcessed, reconnectsTotal)
+}
+
 // EssentialData matches the Rust struct for parsed Solana data
 type EssentialData struct {
-	ProgramID            string                 `json:"program_id"`
-	TokenMint            *string                `json:"token_mint"`
-	TransactionSignature string                 `json:"transaction_signature"`
-	InstructionType      string                 `json:"instruction_type"`
-	InstructionData      map[string]interface{} `json:"instruction_data"`
-	BlockchainTimestamp  int64                  `json:"blockchain_timestamp"`
-	IngestionTimestamp   int64                  `json:"ingestion_timestamp"`
-	Slot                 uint64                 `json:"slot"`
+	ProgramID            string                  `json:"program_id"`
+	TokenMint            *string                 `json:"token_mint"`
+	TransactionSignature string                  `json:"transaction_signature"`
+	InstructionType      string                  `json:"instruction_type"`
+	InstructionData      map[string]interface{}  `json:"instruction_data"`
+	BlockchainTimestamp  int64                   `json:"blockchain_timestamp"`
+	IngestionTimestamp   int64                   `json:"ingestion_timestamp"`
+	Slot                 uint64                  `json:"slot"`
 	Metadata             *map[string]interface{} `json:"metadata"`
 }
 
+const (
+	minReconnectDelay = 500 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+	// resetStreakLength is how many events must be processed in a row on a
+	// connection before the backoff resets to minReconnectDelay.
+	resetStreakLength = 10
+)
+
 // SSEClient handles Server-Sent Events connection to the Rust application
 type SSEClient struct {
 	url    string
 	logger *logrus.Logger
+
+	persistPath string
+
+	mu            sync.Mutex
+	lastEventID   string
+	serverRetry   time.Duration
+	reconnectWait time.Duration
+}
+
+// Option configures an SSEClient at construction time.
+type Option func(*SSEClient)
+
+// ResumeFromID makes the client send the given id as Last-Event-ID on its
+// first connection attempt, resuming the stream rather than replaying it
+// from the start.
+func ResumeFromID(id string) Option {
+	return func(c *SSEClient) {
+		c.lastEventID = id
+	}
+}
+
+// WithLogLevel sets the client's log level (debug, info, warn, error),
+// overriding the InfoLevel default.
+func WithLogLevel(level string) Option {
+	return func(c *SSEClient) {
+		parsed, err := logrus.ParseLevel(level)
+		if err != nil {
+			return
+		}
+		c.logger.SetLevel(parsed)
+	}
+}
+
+// WithLogFormat sets the client's log formatter ("json" or "text").
+func WithLogFormat(format string) Option {
+	return func(c *SSEClient) {
+		if format == "json" {
+			c.logger.SetFormatter(&logrus.JSONFormatter{})
+		} else {
+			c.logger.SetFormatter(&logrus.TextFormatter{})
+		}
+	}
+}
+
+// WithPersistPath makes the client persist the last seen event id to disk
+// after every event, seeding lastEventID from that file (if present) at
+// construction time, so a restart of the Go process resumes mid-stream
+// instead of gapping the ledger.
+func WithPersistPath(path string) Option {
+	return func(c *SSEClient) {
+		c.persistPath = path
+		if data, err := os.ReadFile(path); err == nil {
+			c.lastEventID = strings.TrimSpace(string(data))
+		}
+	}
 }
 
 // NewSSEClient creates a new SSE client
-func NewSSEClient(url string) *SSEClient {
+func NewSSEClient(url string, opts ...Option) *SSEClient {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
-	
-	return &SSEClient{
-		url:    url,
-		logger: logger,
+
+	c := &SSEClient{
+		url:           url,
+		logger:        logger,
+		reconnectWait: minReconnectDelay,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
-// Connect establishes connection to the SSE stream with automatic reconnection
+func (c *SSEClient) persistLastEventID(id string) {
+	if c.persistPath == "" || id == "" {
+		return
+	}
+	if err := os.WriteFile(c.persistPath, []byte(id), 0o644); err != nil {
+		c.logger.WithError(err).Warn("Failed to persist last event id")
+	}
+}
+
+// Connect establishes connection to the SSE stream with automatic
+// reconnection, backing off exponentially (with jitter) between attempts.
 func (c *SSEClient) Connect(ctx context.Context) error {
 	for {
 		select {
@@ -51,49 +153,91 @@ func (c *SSEClient) Connect(ctx context.Context) error {
 			return ctx.Err()
 		default:
 			if err := c.connectOnce(ctx); err != nil {
-				c.logger.WithError(err).Error("SSE connection failed, retrying in 5 seconds...")
-				time.Sleep(5 * time.Second)
+				reconnectsTotal.Inc()
+				delay := c.nextReconnectDelay()
+				c.logger.WithError(err).WithField("retry_in", delay).Error("SSE connection failed, reconnecting...")
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(delay):
+				}
 				continue
 			}
 		}
 	}
 }
 
+// nextReconnectDelay returns the delay to wait before the next reconnect
+// attempt, honoring a server-suggested `retry:` value when present and
+// otherwise doubling the previous delay up to maxReconnectDelay, with
+// jitter to avoid a thundering herd of reconnecting clients.
+func (c *SSEClient) nextReconnectDelay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := c.reconnectWait
+	if c.serverRetry > 0 {
+		base = c.serverRetry
+	}
+
+	jittered := time.Duration(float64(base) * (0.5 + rand.Float64()))
+	if jittered > maxReconnectDelay {
+		jittered = maxReconnectDelay
+	}
+
+	next := c.reconnectWait * 2
+	if next > maxReconnectDelay {
+		next = maxReconnectDelay
+	}
+	c.reconnectWait = next
+
+	return jittered
+}
+
 // connectOnce handles a single connection attempt
 func (c *SSEClient) connectOnce(ctx context.Context) error {
 	c.logger.WithField("url", c.url).Info("Connecting to SSE stream...")
-	
+
 	req, err := http.NewRequestWithContext(ctx, "GET", c.url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
-	
+
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
-	
+
+	c.mu.Lock()
+	lastEventID := c.lastEventID
+	c.mu.Unlock()
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
 	client := &http.Client{
 		Timeout: 0, // No timeout for SSE connections
 	}
-	
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
-	
+
 	c.logger.Info("Connected to SSE stream successfully")
-	
+
 	scanner := bufio.NewScanner(resp.Body)
 	var eventType string
+	var eventID string
 	var data strings.Builder
-	
+	var streak int
+
 	for scanner.Scan() {
 		line := scanner.Text()
-		
+
 		// Handle different SSE line types
 		switch {
 		case strings.HasPrefix(line, "event:"):
@@ -101,25 +245,47 @@ func (c *SSEClient) connectOnce(ctx context.Context) error {
 		case strings.HasPrefix(line, "data:"):
 			dataLine := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
 			data.WriteString(dataLine)
+		case strings.HasPrefix(line, "id:"):
+			eventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil {
+				c.mu.Lock()
+				c.serverRetry = time.Duration(ms) * time.Millisecond
+				c.mu.Unlock()
+			}
 		case line == "":
 			// Empty line indicates end of event
 			if eventType != "" && data.Len() > 0 {
 				c.handleEvent(eventType, data.String())
 				eventType = ""
 				data.Reset()
+
+				if eventID != "" {
+					c.mu.Lock()
+					c.lastEventID = eventID
+					c.mu.Unlock()
+					c.persistLastEventID(eventID)
+					eventID = ""
+				}
+
+				streak++
+				if streak >= resetStreakLength {
+					c.mu.Lock()
+					c.reconnectWait = minReconnectDelay
+					c.mu.Unlock()
+					streak = 0
+				}
 			}
-		case strings.HasPrefix(line, "id:"):
-			// Event ID (not used in this example)
 		case strings.HasPrefix(line, ":"):
 			// Comment line (heartbeat)
 			c.logger.Debug("Received heartbeat")
 		}
 	}
-	
+
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("scanner error: %w", err)
 	}
-	
+
 	return fmt.Errorf("connection closed")
 }
 
@@ -143,29 +309,31 @@ func (c *SSEClient) handleInstructionEvent(data string) {
 		c.logger.WithError(err).Error("Failed to parse instruction data")
 		return
 	}
-	
+
 	// Process the instruction data
 	c.processInstruction(&essentialData)
 }
 
 // processInstruction handles the business logic for processing instructions
 func (c *SSEClient) processInstruction(data *EssentialData) {
+	instructionsProcessed.WithLabelValues(data.ProgramID).Inc()
+
 	c.logger.WithFields(logrus.Fields{
-		"program_id":         data.ProgramID,
-		"instruction_type":   data.InstructionType,
-		"transaction_sig":    data.TransactionSignature,
-		"slot":              data.Slot,
-		"blockchain_time":   time.Unix(data.BlockchainTimestamp, 0),
-		"ingestion_time":    time.Unix(data.IngestionTimestamp, 0),
+		"program_id":       data.ProgramID,
+		"instruction_type": data.InstructionType,
+		"transaction_sig":  data.TransactionSignature,
+		"slot":             data.Slot,
+		"blockchain_time":  time.Unix(data.BlockchainTimestamp, 0),
+		"ingestion_time":   time.Unix(data.IngestionTimestamp, 0),
 	}).Info("Processed instruction")
-	
+
 	// Add your custom business logic here
 	// For example:
 	// - Store in local database
 	// - Forward to another service
 	// - Trigger alerts based on certain conditions
 	// - Calculate statistics
-	
+
 	// Example: Log token transfers
 	if data.InstructionType == "transfer" && data.TokenMint != nil {
 		c.logger.WithFields(logrus.Fields{
@@ -178,22 +346,41 @@ func (c *SSEClient) processInstruction(data *EssentialData) {
 func main() {
 	// Configuration
 	sseURL := "http://localhost:8080/events/stream"
-	if len(log.Args()) > 1 {
-		sseURL = log.Args()[1]
+	if len(os.Args) > 1 {
+		sseURL = os.Args[1]
 	}
-	
-	// Create SSE client
-	client := NewSSEClient(sseURL)
-	
+
+	// Create SSE client. LOG_LEVEL/LOG_FORMAT mirror go-pipeline's
+	// LoggingConfig field names but are read from the environment rather
+	// than config.yaml, since this binary lives in its own module and can't
+	// import go-pipeline/internal/config across the module boundary.
+	var opts []Option
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		opts = append(opts, WithLogLevel(level))
+	}
+	if format := os.Getenv("LOG_FORMAT"); format != "" {
+		opts = append(opts, WithLogFormat(format))
+	}
+	client := NewSSEClient(sseURL, opts...)
+
+	// Serve Prometheus metrics for this client process
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		if err := http.ListenAndServe(":9090", mux); err != nil {
+			log.Printf("metrics server stopped: %v", err)
+		}
+	}()
+
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	
+
 	// Start the client
 	log.Printf("Starting Solana Stream Processor Go Client")
 	log.Printf("Connecting to: %s", sseURL)
-	
+
 	if err := client.Connect(ctx); err != nil {
 		log.Fatalf("Client failed: %v", err)
 	}
-}
\ No newline at end of file
+}